@@ -34,6 +34,14 @@ type ACL struct {
 }
 
 func (odbi *ovndb) getACLUUIDByRow(lsw, table string, row OVNRow) (string, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "acls"); err != nil {
+		return "", err
+	}
+	for field := range row {
+		if err := odbi.requireColumns(TableACL, field); err != nil {
+			return "", err
+		}
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -165,7 +173,10 @@ func (odbi *ovndb) aclAddImp(lsw, direct, match, action string, priority int, ex
 	row["action"] = action
 	row["log"] = logflag
 	if logflag {
-		ok := odbi.meterFind(meter)
+		ok, err := odbi.meterFind(meter)
+		if err != nil {
+			return nil, err
+		}
 		if ok {
 			row["meter"] = meter
 		}
@@ -283,16 +294,23 @@ func (odbi *ovndb) rowToACL(uuid string) *ACL {
 	default:
 	}
 
+	action, _ := cacheACL.Fields["action"].(string)
+	direction, _ := cacheACL.Fields["direction"].(string)
+	match, _ := cacheACL.Fields["match"].(string)
+	priority, _ := cacheACL.Fields["priority"].(int)
+	log, _ := cacheACL.Fields["log"].(bool)
+	extIDs, _ := cacheACL.Fields["external_ids"].(libovsdb.OvsMap)
+
 	acl := &ACL{
 		UUID:       uuid,
-		Action:     cacheACL.Fields["action"].(string),
-		Direction:  cacheACL.Fields["direction"].(string),
-		Match:      cacheACL.Fields["match"].(string),
-		Priority:   cacheACL.Fields["priority"].(int),
-		Log:        cacheACL.Fields["log"].(bool),
+		Action:     action,
+		Direction:  direction,
+		Match:      match,
+		Priority:   priority,
+		Log:        log,
 		Meter:      meter,
 		Severity:   severity,
-		ExternalID: cacheACL.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		ExternalID: extIDs.GoMap,
 	}
 
 	return acl
@@ -300,6 +318,9 @@ func (odbi *ovndb) rowToACL(uuid string) *ACL {
 
 // Get all acl by lswitch
 func (odbi *ovndb) aclListImp(lsw string) ([]*ACL, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "acls"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
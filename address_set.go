@@ -60,7 +60,9 @@ func (odbi *ovndb) asAddImp(name string, addrs []string, external_ids map[string
 	row["name"] = name
 	//should support the -is-exist flag here.
 
-	if uuid := odbi.getRowUUID(TableAddressSet, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableAddressSet, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -113,6 +115,9 @@ func (odbi *ovndb) asDelImp(name string) (*OvnCommand, error) {
 
 // Get all addressset
 func (odbi *ovndb) asListImp() ([]*AddressSet, error) {
+	if err := odbi.requireTable(TableAddressSet); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -122,29 +127,33 @@ func (odbi *ovndb) asListImp() ([]*AddressSet, error) {
 	}
 
 	listAS := make([]*AddressSet, 0, len(cacheAddressSet))
-	for uuid, drows := range cacheAddressSet {
-		ta := &AddressSet{
-			UUID:       uuid,
-			Name:       drows.Fields["name"].(string),
-			ExternalID: drows.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
-		}
-		addresses := []string{}
-		as := drows.Fields["addresses"]
-		switch as.(type) {
-		case libovsdb.OvsSet:
-			//TODO: is it possible return interface type directly instead of GoSet
-			if goset, ok := drows.Fields["addresses"].(libovsdb.OvsSet); ok {
-				for _, i := range goset.GoSet {
-					addresses = append(addresses, i.(string))
-				}
-			}
-		case string:
-			if v, ok := drows.Fields["addresses"].(string); ok {
-				addresses = append(addresses, v)
-			}
-		}
-		ta.Addresses = addresses
-		listAS = append(listAS, ta)
+	for uuid := range cacheAddressSet {
+		listAS = append(listAS, odbi.rowToAddressSet(uuid))
 	}
 	return listAS, nil
 }
+
+func (odbi *ovndb) rowToAddressSet(uuid string) *AddressSet {
+	cacheAddressSet, ok := odbi.cache[TableAddressSet][uuid]
+	if !ok {
+		return nil
+	}
+	name, _ := cacheAddressSet.Fields["name"].(string)
+	extIDs, _ := cacheAddressSet.Fields["external_ids"].(libovsdb.OvsMap)
+	ta := &AddressSet{
+		UUID:       uuid,
+		Name:       name,
+		ExternalID: extIDs.GoMap,
+	}
+	addresses := []string{}
+	switch as := cacheAddressSet.Fields["addresses"].(type) {
+	case libovsdb.OvsSet:
+		for _, i := range as.GoSet {
+			addresses = append(addresses, i.(string))
+		}
+	case string:
+		addresses = append(addresses, as)
+	}
+	ta.Addresses = addresses
+	return ta
+}
@@ -24,7 +24,8 @@ import (
 type OvnCommand struct {
 	Operations []libovsdb.Operation
 	Exe        Execution
-	Results    [][]map[string]interface{}
+	// Results of the operations once executed, see operationResult
+	Results [][]map[string]interface{}
 }
 
 // Execute sends command to ovnnb
@@ -32,6 +33,39 @@ func (ocmd *OvnCommand) Execute() error {
 	return ocmd.Exe.Execute(ocmd)
 }
 
+// CreatedUUID returns the UUID of the row inserted by the executed command, the last one
+// if it inserts several, e.g. the Meter and not its Meter_Band for MeterAdd.
+// It returns ErrorNotFound if the command inserts no row or was not executed.
+func (ocmd *OvnCommand) CreatedUUID() (string, error) {
+	for i := len(ocmd.Operations) - 1; i >= 0; i-- {
+		if ocmd.Operations[i].Op != opInsert {
+			continue
+		}
+		if i < len(ocmd.Results) && len(ocmd.Results[i]) == 1 {
+			if uuid, ok := ocmd.Results[i][0]["uuid"].(string); ok && uuid != "" {
+				return uuid, nil
+			}
+		}
+		break
+	}
+	return "", ErrorNotFound
+}
+
+// Verify makes the command fail with ErrorConflict if the rows it was validated
+// against change before it is executed, like Config.VerifyCache for all commands.
+func (ocmd *OvnCommand) Verify() error {
+	odbi, ok := ocmd.Exe.(*ovndb)
+	if !ok {
+		return ErrorOption
+	}
+	waits, err := odbi.waitOperations(ocmd.Operations...)
+	if err != nil {
+		return err
+	}
+	ocmd.Operations = append(waits, ocmd.Operations...)
+	return nil
+}
+
 // Execution executes multiple ovnnb commands
 type Execution interface {
 	//Excute multi-commands
@@ -41,7 +75,14 @@ type Execution interface {
 // OVNDisconnectedCallback executed when ovn client disconnects
 type OVNDisconnectedCallback func()
 
-// OVNSignal notifies on changes to ovnnb
+// OVNReconnectingCallback executed before each attempt to reconnect, err is why the
+// previous attempt failed, nil for the first one
+type OVNReconnectingCallback func(attempt int, err error)
+
+// OVNReconnectedCallback executed once reconnected, after attempts attempts
+type OVNReconnectedCallback func(attempts int)
+
+// OVNSignal notifies on changes to ovnnb, see Client.Subscribe for events of any table
 type OVNSignal interface {
 	OnLogicalSwitchCreate(ls *LogicalSwitch)
 	OnLogicalSwitchDelete(ls *LogicalSwitch)
@@ -85,11 +126,31 @@ type OVNSignal interface {
 	OnEncapDelete(ch *Encap)
 }
 
+// OVNUpdateSignal is implemented by an OVNSignal to be notified of modified rows with their
+// values before and after the modification. Otherwise modified rows are notified as created.
+type OVNUpdateSignal interface {
+	OnLogicalSwitchUpdate(old, new *LogicalSwitch)
+	OnLogicalPortUpdate(old, new *LogicalSwitchPort)
+	OnLogicalRouterUpdate(old, new *LogicalRouter)
+	OnLogicalRouterPortUpdate(old, new *LogicalRouterPort)
+	OnLogicalRouterStaticRouteUpdate(old, new *LogicalRouterStaticRoute)
+	OnACLUpdate(old, new *ACL)
+	OnDHCPOptionsUpdate(old, new *DHCPOptions)
+	OnQoSUpdate(old, new *QoS)
+	OnLoadBalancerUpdate(old, new *LoadBalancer)
+	OnMeterUpdate(old, new *Meter)
+	OnMeterBandUpdate(old, new *MeterBand)
+	OnChassisUpdate(old, new *Chassis)
+	OnEncapUpdate(old, new *Encap)
+}
+
 // OVNNotifier ovnnb and ovnsb notifier
 type OVNNotifier interface {
 	Update(context interface{}, tableUpdates libovsdb.TableUpdates)
 	Locked([]interface{})
 	Stolen([]interface{})
 	Echo([]interface{})
+	// Disconnected is called once the connection is gone. go-ovn has its own connection
+	// since RFC 7047 calls take a context, client is always nil.
 	Disconnected(client *libovsdb.OvsdbClient)
 }
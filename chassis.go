@@ -29,10 +29,20 @@ type Chassis struct {
 	Hostname            string
 	Name                string
 	NbCfg               int
+	OtherConfig         map[interface{}]interface{}
 	TransportZones      []string
 	VtepLogicalSwitches []string
 }
 
+// ChassisPrivate ovnsb item, where ovn-controller reports nb_cfg since OVN 20.09
+type ChassisPrivate struct {
+	UUID       string
+	Name       string
+	Chassis    string
+	NbCfg      int
+	ExternalID map[interface{}]interface{}
+}
+
 func (odbi *ovndb) chassisAddImp(name string, hostname string, etype []string, ip string,
 	external_ids map[string]string, transport_zones []string, vtep_lswitches []string) (*OvnCommand, error) {
 	if len(name) == 0 {
@@ -61,7 +71,9 @@ func (odbi *ovndb) chassisAddImp(name string, hostname string, etype []string, i
 		encap_ids = append(encap_ids, encap_id)
 		row["ip"] = ip
 		row["type"] = et
-		if uuid := odbi.getRowUUID(TableEncap, row); len(uuid) > 0 {
+		if uuid, err := odbi.getRowUUID(TableEncap, row); err != nil {
+			return nil, err
+		} else if len(uuid) > 0 {
 			return nil, ErrorExist
 		}
 		insertEncapOp := libovsdb.Operation{
@@ -123,7 +135,158 @@ func (odbi *ovndb) chassisDelImp(name string) (*OvnCommand, error) {
 	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
 }
 
+// chassisUpdate sets column of the chassis named chassis to value
+func (odbi *ovndb) chassisUpdate(chassis, column string, value interface{}, operations ...libovsdb.Operation) (*OvnCommand, error) {
+	if _, err := odbi.chassisUUID(chassis); err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row[column] = value
+	condition := libovsdb.NewCondition("name", "==", chassis)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableChassis,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations = append(operations, updateOp)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) chassisSetHostnameImp(chassis, hostname string) (*OvnCommand, error) {
+	return odbi.chassisUpdate(chassis, "hostname", hostname)
+}
+
+func (odbi *ovndb) chassisSetExternalIDsImp(chassis string, external_ids map[string]string) (*OvnCommand, error) {
+	if external_ids == nil {
+		external_ids = map[string]string{}
+	}
+	oMap, err := libovsdb.NewOvsMap(external_ids)
+	if err != nil {
+		return nil, err
+	}
+	return odbi.chassisUpdate(chassis, "external_ids", oMap)
+}
+
+func (odbi *ovndb) chassisSetOtherConfigImp(chassis string, other_config map[string]string) (*OvnCommand, error) {
+	if other_config == nil {
+		other_config = map[string]string{}
+	}
+	oMap, err := libovsdb.NewOvsMap(other_config)
+	if err != nil {
+		return nil, err
+	}
+	return odbi.chassisUpdate(chassis, "other_config", oMap)
+}
+
+func (odbi *ovndb) chassisSetTransportZonesImp(chassis string, transport_zones []string) (*OvnCommand, error) {
+	tzs := libovsdb.OvsSet{GoSet: []interface{}{}}
+	for _, tz := range transport_zones {
+		tzs.GoSet = append(tzs.GoSet, tz)
+	}
+	return odbi.chassisUpdate(chassis, "transport_zones", tzs)
+}
+
+// chassisSetEncapsImp replaces the encaps of chassis like chassisAddImp creates them,
+// the previous ones are garbage collected
+func (odbi *ovndb) chassisSetEncapsImp(chassis string, etype []string, ip string) (*OvnCommand, error) {
+	if len(etype) == 0 {
+		return nil, fmt.Errorf("chassis encap type cannot be empty")
+	}
+	if len(ip) == 0 {
+		return nil, fmt.Errorf("chassis ip cannot be empty")
+	}
+	var operations []libovsdb.Operation
+	encaps := libovsdb.OvsSet{GoSet: []interface{}{}}
+	for _, et := range etype {
+		row := make(OVNRow)
+		row["ip"] = ip
+		row["type"] = et
+		all, err := odbi.getRowUUIDs(TableEncap, row)
+		if err != nil {
+			return nil, err
+		}
+		row["chassis_name"] = chassis
+		owned, err := odbi.getRowUUIDs(TableEncap, row)
+		if err != nil {
+			return nil, err
+		}
+		if len(all) > len(owned) {
+			// the encap of another chassis
+			return nil, ErrorExist
+		}
+		enCapUUID, err := newRowUUID()
+		if err != nil {
+			return nil, err
+		}
+		insertEncapOp := libovsdb.Operation{
+			Op:       opInsert,
+			Table:    TableEncap,
+			Row:      row,
+			UUIDName: enCapUUID,
+		}
+		operations = append(operations, insertEncapOp)
+		encaps.GoSet = append(encaps.GoSet, stringToGoUUID(enCapUUID))
+	}
+	return odbi.chassisUpdate(chassis, "encaps", encaps, operations...)
+}
+
+// chassisListLaggingImp returns the chassis whose nb_cfg is more than maxLag behind the one of
+// SB_Global. It is the one of Chassis_Private if the chassis has a row there, ovn-controller
+// only reports it in Chassis before OVN 20.09.
+func (odbi *ovndb) chassisListLaggingImp(maxLag int) ([]*Chassis, error) {
+	if err := odbi.requireColumns(TableSBGlobal, "nb_cfg"); err != nil {
+		return nil, err
+	}
+	if err := odbi.requireColumns(TableChassis, "name", "nb_cfg"); err != nil {
+		return nil, err
+	}
+	schema, _ := odbi.getSchema()
+	if _, ok := schema.Tables[TableChassisPrivate]; ok {
+		if err := odbi.requireTable(TableChassisPrivate); err != nil {
+			return nil, err
+		}
+		if err := odbi.requireColumns(TableChassisPrivate, "name", "nb_cfg"); err != nil {
+			return nil, err
+		}
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	nbCfg := -1
+	for _, drows := range odbi.cache[TableSBGlobal] {
+		nbCfg, _ = drows.Fields["nb_cfg"].(int)
+	}
+	if nbCfg == -1 {
+		return nil, errorf(ErrorNotFound, "no row in %s table", TableSBGlobal)
+	}
+
+	privateNbCfg := make(map[string]int)
+	for uuid := range odbi.cache[TableChassisPrivate] {
+		chp := odbi.rowToChassisPrivate(uuid)
+		privateNbCfg[chp.Name] = chp.NbCfg
+	}
+
+	var listChassis []*Chassis
+	for uuid := range odbi.cache[TableChassis] {
+		ch, err := odbi.rowToChassis(uuid)
+		if err != nil {
+			return nil, err
+		}
+		if chNbCfg, ok := privateNbCfg[ch.Name]; ok {
+			ch.NbCfg = chNbCfg
+		}
+		if nbCfg-ch.NbCfg > maxLag {
+			listChassis = append(listChassis, ch)
+		}
+	}
+	return listChassis, nil
+}
+
 func (odbi *ovndb) chassisListImp() ([]*Chassis, error) {
+	if err := odbi.requireTable(TableChassis); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -145,6 +308,9 @@ func (odbi *ovndb) chassisListImp() ([]*Chassis, error) {
 }
 
 func (odbi *ovndb) chassisGetImp(chassis string) ([]*Chassis, error) {
+	if err := odbi.requireColumns(TableChassis, "name", "hostname"); err != nil {
+		return nil, err
+	}
 	var listChassis []*Chassis
 
 	odbi.cachemutex.RLock()
@@ -180,12 +346,18 @@ func (odbi *ovndb) rowToChassis(uuid string) (*Chassis, error) {
 	if !ok {
 		return nil, fmt.Errorf("Chassis with uuid%s not found", uuid)
 	}
+	name, _ := cacheChassis.Fields["name"].(string)
+	hostname, _ := cacheChassis.Fields["hostname"].(string)
+	extIDs, _ := cacheChassis.Fields["external_ids"].(libovsdb.OvsMap)
+	nbCfg, _ := cacheChassis.Fields["nb_cfg"].(int)
+	otherConfig, _ := cacheChassis.Fields["other_config"].(libovsdb.OvsMap)
 	ch := &Chassis{
-		UUID:       uuid,
-		Name:       cacheChassis.Fields["name"].(string),
-		Hostname:   cacheChassis.Fields["hostname"].(string),
-		ExternalID: cacheChassis.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
-		NbCfg:      cacheChassis.Fields["nb_cfg"].(int),
+		UUID:        uuid,
+		Name:        name,
+		Hostname:    hostname,
+		ExternalID:  extIDs.GoMap,
+		NbCfg:       nbCfg,
+		OtherConfig: otherConfig.GoMap,
 	}
 
 	if tz, ok := cacheChassis.Fields["transport_zones"]; ok {
@@ -228,3 +400,24 @@ func (odbi *ovndb) rowToChassis(uuid string) (*Chassis, error) {
 	ch.Encaps = encaps
 	return ch, nil
 }
+
+func (odbi *ovndb) rowToChassisPrivate(uuid string) *ChassisPrivate {
+	cacheChassisPrivate, ok := odbi.cache[TableChassisPrivate][uuid]
+	if !ok {
+		return nil
+	}
+
+	name, _ := cacheChassisPrivate.Fields["name"].(string)
+	nbCfg, _ := cacheChassisPrivate.Fields["nb_cfg"].(int)
+	extIDs, _ := cacheChassisPrivate.Fields["external_ids"].(libovsdb.OvsMap)
+	chp := &ChassisPrivate{
+		UUID:       uuid,
+		Name:       name,
+		NbCfg:      nbCfg,
+		ExternalID: extIDs.GoMap,
+	}
+	if chassis, ok := cacheChassisPrivate.Fields["chassis"].(libovsdb.UUID); ok {
+		chp.Chassis = chassis.GoUUID
+	}
+	return chp
+}
@@ -17,7 +17,14 @@
 package goovn
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+
+	ovsdbtest "github.com/ebay/go-ovn/testing"
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
@@ -148,3 +155,214 @@ func TestChassis(t *testing.T) {
 	}
 	t.Logf("Chassis %s deleted", chName)
 }
+
+func TestChassisUpdate(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	cmds := make([]*OvnCommand, 0, 2)
+	for _, name := range []string{CHASSIS_NAME, CHASSIS2_NAME} {
+		cmd, err := ovndbapi.ChassisAdd(name, name, []string{"geneve"}, name, nil, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	err := ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmds := make([]*OvnCommand, 0, 2)
+		for _, name := range []string{CHASSIS_NAME, CHASSIS2_NAME} {
+			cmd, err := ovndbapi.ChassisDel(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cmds = append(cmds, cmd)
+		}
+		err := ovndbapi.Execute(cmds...)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	cmds = cmds[:0]
+	cmd, err := ovndbapi.ChassisSetHostname(CHASSIS_NAME, CHASSIS_HOSTNAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.ChassisSetExternalIDs(CHASSIS_NAME, map[string]string{"rack": "r1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.ChassisSetOtherConfig(CHASSIS_NAME, map[string]string{"datapath-type": "system"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.ChassisSetTransportZones(CHASSIS_NAME, []string{"tz1", "tz2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.ChassisSetEncaps(CHASSIS_NAME, ENCAP_TYPES, IP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chassis, err := ovndbapi.ChassisGet(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(chassis)) {
+		assert.Equal(t, CHASSIS_HOSTNAME, chassis[0].Hostname)
+		assert.Equal(t, "r1", chassis[0].ExternalID["rack"])
+		assert.Equal(t, "system", chassis[0].OtherConfig["datapath-type"])
+		assert.ElementsMatch(t, []string{"tz1", "tz2"}, chassis[0].TransportZones)
+	}
+	encaps, err := ovndbapi.EncapList(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, len(ENCAP_TYPES), len(encaps)) {
+		for _, encap := range encaps {
+			assert.Equal(t, IP, encap.Ip)
+		}
+	}
+
+	// the encaps of a chassis can be set again, not taken from another one
+	cmd, err = ovndbapi.ChassisSetEncaps(CHASSIS_NAME, []string{"geneve"}, IP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encaps, err = ovndbapi.EncapList(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(encaps))
+	_, err = ovndbapi.ChassisSetEncaps(CHASSIS_NAME, []string{"geneve"}, CHASSIS2_NAME)
+	assert.Equal(t, ErrorExist, err)
+	_, err = ovndbapi.ChassisSetHostname(FAKENOCHASSIS, CHASSIS_HOSTNAME)
+	assert.True(t, isError(err, ErrorNotFound), err)
+
+	cmd, err = ovndbapi.ChassisSetTransportZones(CHASSIS_NAME, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chassis, err = ovndbapi.ChassisGet(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(chassis[0].TransportZones))
+}
+
+func TestChassisListLagging(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	_, err := ovndbapi.ChassisListLagging(0)
+	assert.True(t, isError(err, ErrorNotFound), err)
+	testChassisListLagging(t, ovndbapi, true)
+
+	// before OVN 20.09 there is no Chassis_Private
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNSB_SOCKET)
+	srv, err := ovsdbtest.NewServer(ovsdbtest.NBSchema, schemaWithout(t, ovsdbtest.SBSchema, TableChassisPrivate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	if _, err = srv.Listen("unix", socket); err != nil {
+		t.Fatal(err)
+	}
+	api, err := NewClient(&Config{Db: DBSB, Addr: UNIX + ":" + socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	testChassisListLagging(t, api, false)
+}
+
+// testChassisListLagging has ovn-controller report nb_cfg in Chassis_Private if private,
+// or else in Chassis
+func testChassisListLagging(t *testing.T, ovndbapi Client, private bool) {
+	withGlobalRow(t, ovndbapi, func() {
+		cmds := make([]*OvnCommand, 0, 2)
+		for _, name := range []string{CHASSIS_NAME, CHASSIS2_NAME} {
+			cmd, err := ovndbapi.ChassisAdd(name, name, []string{"geneve"}, name, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cmds = append(cmds, cmd)
+		}
+		err := ovndbapi.Execute(cmds...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			cmds := make([]*OvnCommand, 0, 2)
+			for _, name := range []string{CHASSIS_NAME, CHASSIS2_NAME} {
+				cmd, err := ovndbapi.ChassisDel(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				cmds = append(cmds, cmd)
+			}
+			err := ovndbapi.Execute(cmds...)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}()
+
+		// as ovn-northd and ovn-controller would
+		operations := []libovsdb.Operation{
+			{Op: opUpdate, Table: TableSBGlobal, Row: OVNRow{"nb_cfg": 10}, Where: []interface{}{}}}
+		for name, nbCfg := range map[string]int{CHASSIS_NAME: 10, CHASSIS2_NAME: 7} {
+			if private {
+				operations = append(operations, libovsdb.Operation{Op: opInsert, Table: TableChassisPrivate,
+					Row: OVNRow{"name": name, "nb_cfg": nbCfg}})
+			} else {
+				operations = append(operations, libovsdb.Operation{Op: opUpdate, Table: TableChassis,
+					Row: OVNRow{"nb_cfg": nbCfg}, Where: []interface{}{libovsdb.NewCondition("name", "==", name)}})
+			}
+		}
+		sbTransact(t, ovndbapi, operations...)
+		if private {
+			defer sbTransact(t, ovndbapi,
+				libovsdb.Operation{Op: opDelete, Table: TableChassisPrivate, Where: []interface{}{}})
+		}
+
+		chassis, err := ovndbapi.ChassisListLagging(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assert.Equal(t, 1, len(chassis)) {
+			assert.Equal(t, CHASSIS2_NAME, chassis[0].Name)
+			assert.Equal(t, 7, chassis[0].NbCfg)
+		}
+		chassis, err = ovndbapi.ChassisListLagging(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, len(chassis))
+	})
+}
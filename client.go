@@ -17,7 +17,9 @@
 package goovn
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"sync"
 
 	"crypto/tls"
@@ -98,6 +100,27 @@ type Client interface {
 	LRPDel(lr string, lrp string) (*OvnCommand, error)
 	// Get all lrp by lr
 	LRPList(lr string) ([]*LogicalRouterPort, error)
+	// Add gateway chassis to lrp with given priority, or set the priority if it is there
+	LRPGatewayChassisAdd(lrp, chassisName string, priority int) (*OvnCommand, error)
+	// Delete gateway chassis from lrp
+	LRPGatewayChassisDel(lrp, chassisName string) (*OvnCommand, error)
+	// Get gateway chassis of lrp by decreasing priority
+	LRPGatewayChassisList(lrp string) ([]*GatewayChassis, error)
+	// Set HA chassis group of lrp, none if group is empty
+	LRPSetHAChassisGroup(lrp, group string) (*OvnCommand, error)
+
+	// Add HA chassis group
+	HAChassisGroupAdd(group string, external_ids map[string]string) (*OvnCommand, error)
+	// Delete HA chassis group and its chassis
+	HAChassisGroupDel(group string) (*OvnCommand, error)
+	// Get HA chassis group by name
+	HAChassisGroupGet(group string) (*HAChassisGroup, error)
+	// Get all HA chassis groups
+	HAChassisGroupList() ([]*HAChassisGroup, error)
+	// Add chassis to HA chassis group with given priority, or set the priority if it is there
+	HAChassisGroupAddChassis(group, chassisName string, priority int) (*OvnCommand, error)
+	// Delete chassis from HA chassis group
+	HAChassisGroupDelChassis(group, chassisName string) (*OvnCommand, error)
 
 	// Add LRSR with given ip_prefix on given lr
 	LRSRAdd(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*OvnCommand, error)
@@ -108,6 +131,15 @@ type Client interface {
 	// Get all LRSRs by lr
 	LRSRList(lr string) ([]*LogicalRouterStaticRoute, error)
 
+	// Add policy to lr, nexthops are for action reroute
+	LRPolicyAdd(lr string, priority int, match, action string, nexthops []string, external_ids map[string]string) (*OvnCommand, error)
+	// Del policies from lr, to delete wildcard specify priority -1 and match as ""
+	LRPolicyDel(lr string, priority int, match string) (*OvnCommand, error)
+	// Del policy by uuid from lr
+	LRPolicyDelByUUID(lr, uuid string) (*OvnCommand, error)
+	// Get policies of lr by decreasing priority
+	LRPolicyList(lr string) ([]*LogicalRouterPolicy, error)
+
 	// Add LB to LR
 	LRLBAdd(lr string, lb string) (*OvnCommand, error)
 	// Delete LB from LR
@@ -146,6 +178,10 @@ type Client interface {
 	LSPSetExternalIds(lsp string, external_ids map[string]string) (*OvnCommand, error)
 	// Get external_ids from LSP
 	LSPGetExternalIds(lsp string) (map[string]string, error)
+	// Set type of LSP, e.g. "external"
+	LSPSetType(lsp string, portType string) (*OvnCommand, error)
+	// Set HA chassis group of an external LSP, none if group is empty
+	LSPSetHAChassisGroup(lsp, group string) (*OvnCommand, error)
 	// Add dhcp options for cidr and provided external_ids
 	DHCPOptionsAdd(cidr string, options map[string]string, external_ids map[string]string) (*OvnCommand, error)
 	// Set dhcp options and set external_ids for specific uuid
@@ -157,6 +193,23 @@ type Client interface {
 	// List dhcp options
 	DHCPOptionsList() ([]*DHCPOptions, error)
 
+	// Add DNS records and provided external_ids
+	DNSAdd(records map[string]string, external_ids map[string]string) (*OvnCommand, error)
+	// Set DNS records and external_ids for specific uuid
+	DNSSet(uuid string, records map[string]string, external_ids map[string]string) (*OvnCommand, error)
+	// Del DNS via provided uuid
+	DNSDel(uuid string) (*OvnCommand, error)
+	// Get single DNS via provided uuid
+	DNSGet(uuid string) (*DNS, error)
+	// List DNS
+	DNSList() ([]*DNS, error)
+	// Add DNS to logical switch dns_records
+	LSDNSAdd(ls string, uuid string) (*OvnCommand, error)
+	// Delete DNS from logical switch dns_records
+	LSDNSDel(ls string, uuid string) (*OvnCommand, error)
+	// List DNS of logical switch
+	LSDNSList(ls string) ([]*DNS, error)
+
 	// Add qos rule
 	QoSAdd(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*OvnCommand, error)
 	// Del qos rule, to delete wildcard specify priority -1 and string options as ""
@@ -180,6 +233,18 @@ type Client interface {
 	MeterBandsList() ([]*MeterBand, error)
 	// Exec command, support mul-commands in one transaction.
 	Execute(cmds ...*OvnCommand) error
+	// Exec commands like Execute, returning ctx.Err() if ctx is done before the reply arrives.
+	// The transaction may still be committed if ctx is done after it was sent.
+	ExecuteContext(ctx context.Context, cmds ...*OvnCommand) error
+	// Exec commands like ExecuteContext along with an increment of NB_Global nb_cfg, then block
+	// until ovn-northd or all chassis report it in sb_cfg or hv_cfg, or ctx is done
+	ExecuteWait(ctx context.Context, wait WaitFor, cmds ...*OvnCommand) error
+	// Create a transaction to stage multiple commands and commit them at once
+	NewTransaction() Transaction
+	// Run fn in a new transaction and commit it, re-running fn on ErrorConflict
+	Transact(fn func(txn Transaction) error) error
+	// Run fn in a new transaction like Transact, committing it with ctx
+	TransactContext(ctx context.Context, fn func(txn Transaction) error) error
 
 	// Add chassis with given name
 	ChassisAdd(name string, hostname string, etype []string, ip string, external_ids map[string]string,
@@ -190,10 +255,71 @@ type Client interface {
 	ChassisGet(chname string) ([]*Chassis, error)
 	// List chassis
 	ChassisList() ([]*Chassis, error)
+	// Set hostname of chassis
+	ChassisSetHostname(chname string, hostname string) (*OvnCommand, error)
+	// Set external_ids of chassis, replacing the previous ones
+	ChassisSetExternalIDs(chname string, external_ids map[string]string) (*OvnCommand, error)
+	// Set other_config of chassis, replacing the previous one
+	ChassisSetOtherConfig(chname string, other_config map[string]string) (*OvnCommand, error)
+	// Set transport zones of chassis, none if transport_zones is empty
+	ChassisSetTransportZones(chname string, transport_zones []string) (*OvnCommand, error)
+	// Replace encaps of chassis with one of each etype on ip
+	ChassisSetEncaps(chname string, etype []string, ip string) (*OvnCommand, error)
+	// List chassis whose nb_cfg, the one of Chassis_Private if the chassis has a row there,
+	// is more than maxLag behind SB_Global nb_cfg
+	ChassisListLagging(maxLag int) ([]*Chassis, error)
 
 	// Get encaps by chassis name
 	EncapList(chname string) ([]*Encap, error)
 
+	// List port bindings
+	PortBindingList() ([]*PortBinding, error)
+	// Get port binding by logical port
+	PortBindingGet(lport string) (*PortBinding, error)
+	// List port bindings bound to chassis name
+	PortBindingListByChassis(chassis string) ([]*PortBinding, error)
+	// Bind logical port to chassis name, unbind it if chassis is empty
+	PortBindingSetChassis(lport, chassis string) (*OvnCommand, error)
+	// Wait until logical port is bound to a chassis and get its port binding, or ctx is done
+	PortBindingWaitBound(ctx context.Context, lport string) (*PortBinding, error)
+
+	// List datapath bindings, Datapath_Binding must be in Config.TableCols
+	DatapathBindingList() ([]*DatapathBinding, error)
+	// Get datapath binding by uuid
+	DatapathBindingGet(uuid string) (*DatapathBinding, error)
+	// Get datapath binding of the NB logical switch or router uuid
+	DatapathBindingGetByNB(nbUUID string) (*DatapathBinding, error)
+	// List logical flows, Logical_Flow must be in Config.TableCols, and Logical_DP_Group too
+	// to match a datapath since OVN 21.03. To match any specify "" or -1, flows match if their
+	// match contains match
+	LogicalFlowList(datapath, pipeline string, tableID, priority int, match string) ([]*LogicalFlow, error)
+
+	// List MAC bindings
+	MACBindingList() ([]*MACBinding, error)
+	// List MAC bindings by logical port
+	MACBindingListByPort(lport string) ([]*MACBinding, error)
+	// List MAC bindings by datapath binding uuid
+	MACBindingListByDatapath(datapath string) ([]*MACBinding, error)
+	// List MAC bindings of ip
+	MACBindingListByIP(ip string) ([]*MACBinding, error)
+	// Delete MAC binding by uuid
+	MACBindingDel(uuid string) (*OvnCommand, error)
+	// Delete MAC bindings last refreshed more than age ago, ErrorNoChanges if there is none
+	MACBindingDelOlderThan(age time.Duration) (*OvnCommand, error)
+	// Delete MAC bindings f is true for, ErrorNoChanges if there is none
+	MACBindingDelFunc(f func(*MACBinding) bool) (*OvnCommand, error)
+
+	// List FDB entries, ErrorSchema if the database has no FDB table
+	FDBList() ([]*FDB, error)
+	// List FDB entries of the datapath tunnel key dpKey
+	FDBListByDatapath(dpKey int) ([]*FDB, error)
+	// List FDB entries of the port tunnel key portKey of the datapath tunnel key dpKey
+	FDBListByPort(dpKey, portKey int) ([]*FDB, error)
+	// Delete FDB entry by uuid
+	FDBDel(uuid string) (*OvnCommand, error)
+	// Delete FDB entries f is true for, ErrorNoChanges if there is none
+	FDBDelFunc(f func(*FDB) bool) (*OvnCommand, error)
+
 	// Set NB_Global table options
 	NBGlobalSetOptions(options map[string]string) (*OvnCommand, error)
 
@@ -206,6 +332,19 @@ type Client interface {
 	// Get SB_Global table options
 	SBGlobalGetOptions() (map[string]string, error)
 
+	// Replace the connections of the database, none if conns is empty
+	ConnectionSet(conns ...*Connection) (*OvnCommand, error)
+	// Delete all connections of the database
+	ConnectionDel() (*OvnCommand, error)
+	// List connections of the database
+	ConnectionList() ([]*Connection, error)
+	// Replace the SSL configuration of the database
+	SSLSet(ssl *SSLConfig) (*OvnCommand, error)
+	// Delete the SSL configuration of the database
+	SSLDel() (*OvnCommand, error)
+	// Get the SSL configuration of the database
+	SSLGet() (*SSLConfig, error)
+
 	// Creates a new port group in the Port_Group table named "group" with optional "ports" added to the group.
 	PortGroupAdd(group string, ports []string, external_ids map[string]string) (*OvnCommand, error)
 	// Sets "ports" and/or "external_ids" on the port group named "group". It is an error if group does not exist.
@@ -215,49 +354,127 @@ type Client interface {
 	// Get PortGroup data structure if it exists
 	PortGroupGet(group string) (*PortGroup, error)
 
-	// Close connection to OVN
+	// Monitor only the rows of table matching any of the conditions in where, e.g.
+	// libovsdb.NewCondition("external_ids", "includes", ...), or all rows if there are none.
+	// The cache is updated before it returns, rows entering or leaving it are signaled as
+	// created or deleted.
+	SetTableConds(table string, where ...interface{}) error
+	// SetTableConds giving up with ctx.Err() when ctx is done
+	SetTableCondsContext(ctx context.Context, table string, where ...interface{}) error
+
+	// Subscribe calls handler with the events of table, or of all tables if it is empty,
+	// for which all the predicates hold, queued as set by Config.EventQueue. Handlers run
+	// in a goroutine of the subscription once the cache holds the changes, so they may call
+	// the client, which is not held up by them unless the queue is full with OverflowBlock.
+	Subscribe(table string, handler EventHandler, predicates ...EventPredicate) *Subscription
+	// Subscribe with the events queued as set by queue
+	SubscribeQueue(table string, handler EventHandler, queue QueueConfig, predicates ...EventPredicate) *Subscription
+
+	// Tell whether the client is connected, it is not while reconnecting
+	Connected() bool
+
+	// Close connection to OVN, stopping reconnection
 	Close() error
 }
 
 var _ Client = &ovndb{}
 
 type ovndb struct {
-	client       *libovsdb.OvsdbClient
+	client       *ovsdbClient
 	cache        map[string]map[string]libovsdb.Row
 	cachemutex   sync.RWMutex
-	tranmutex    sync.Mutex
-	signalCB     OVNSignal
+	tranlock     chan struct{}
 	disconnectCB OVNDisconnectedCallback
 	db           string
 	addr         string
 	tableCols    map[string][]string
+	tableConds   map[string][]interface{}
+	condmutex    sync.Mutex
+	condMonitor  bool
 	tlsConfig    *tls.Config
 	reconn       bool
-}
-
-func connect(c *ovndb) (err error) {
-	ovsdb, err := libovsdb.Connect(c.addr, c.tlsConfig)
+	verify       bool
+	txn          *transaction
+
+	backoff        Backoff
+	reconnectingCB OVNReconnectingCallback
+	reconnectedCB  OVNReconnectedCallback
+	// done once the client is closed, cancels reconnection
+	done   context.Context
+	cancel context.CancelFunc
+	// protects client, connected, serverIndex and lastTxnID
+	connmutex sync.Mutex
+	connected bool
+	// the id of the last transaction applied to the cache, for monitor_cond_since
+	lastTxnID string
+
+	leaderOnly      bool
+	inactivityProbe time.Duration
+	// the highest raft index of the database seen, members behind it are stale
+	serverIndex int
+	// index in addr of the remote to try first
+	nextRemote int
+
+	submutex      sync.Mutex
+	subscriptions []*Subscription
+	eventQueue    QueueConfig
+}
+
+func connect(ctx context.Context, c *ovndb) (err error) {
+	ovsdb, err := c.connectMember(ctx)
 	if err != nil {
 		return err
 	}
+	c.connmutex.Lock()
 	c.client = ovsdb
+	c.connmutex.Unlock()
 	defer func() {
 		if err != nil {
-			c.client.Disconnect()
+			c.connmutex.Lock()
 			c.client = nil
+			c.connmutex.Unlock()
+			ovsdb.disconnect()
 		}
 	}()
-	initial, err := c.MonitorTables("")
+	if _, err = ovsdb.getSchema(ctx, c.db); err != nil {
+		return err
+	}
+	initial, found, txnID, err := c.monitorTables(ctx, "", c.lastTxn())
 	if err != nil {
 		return err
 	}
-	c.populateCache(*initial)
-	notifier := ovnNotifier{c}
-	ovsdb.Register(notifier)
+	if found {
+		// the changes since the last transaction applied before disconnecting
+		c.populateCache(*initial)
+	} else {
+		c.resyncCache(*initial)
+	}
+	c.setLastTxn(txnID)
+	c.connmutex.Lock()
+	if c.done.Err() != nil {
+		// closed meanwhile
+		c.connmutex.Unlock()
+		return c.done.Err()
+	}
+	c.connected = true
+	c.connmutex.Unlock()
+	// the updates and the disconnect since the monitor request, which was sent with the
+	// notifier registered by connectMember
+	ovsdb.release()
+	if c.inactivityProbe > 0 {
+		// a dead connection is closed, which reconnects or calls disconnectCB
+		go ovsdb.probe(c.inactivityProbe)
+	}
 	return nil
 }
 
 func NewClient(cfg *Config) (Client, error) {
+	return NewClientContext(context.Background(), cfg)
+}
+
+// NewClientContext creates a client like NewClient, returning ctx.Err() if ctx
+// is done before it is connected and monitoring the database.
+func NewClientContext(ctx context.Context, cfg *Config) (Client, error) {
 	db := cfg.Db
 	// db string should strictly be OVN_Northbound or OVN_Southbound
 	switch db {
@@ -271,47 +488,129 @@ func NewClient(cfg *Config) (Client, error) {
 
 	ovndb := &ovndb{
 		cache:        make(map[string]map[string]libovsdb.Row),
-		signalCB:     cfg.SignalCB,
+		tranlock:     make(chan struct{}, 1),
 		disconnectCB: cfg.DisconnectCB,
 		db:           db,
 		tableCols:    cfg.TableCols,
+		tableConds:   make(map[string][]interface{}),
 		addr:         cfg.Addr,
 		tlsConfig:    cfg.TLSConfig,
 		reconn:       cfg.Reconnect,
+		verify:       cfg.VerifyCache,
+		eventQueue:   cfg.EventQueue,
+
+		leaderOnly:      cfg.LeaderOnly,
+		inactivityProbe: cfg.InactivityProbe,
+		backoff:         cfg.ReconnectBackoff,
+		reconnectingCB:  cfg.OnReconnecting,
+		reconnectedCB:   cfg.OnReconnected,
+	}
+	ovndb.done, ovndb.cancel = context.WithCancel(context.Background())
+	if ovndb.backoff.Initial <= 0 {
+		ovndb.backoff.Initial = 500 * time.Millisecond
+	}
+	if ovndb.backoff.Max <= 0 {
+		ovndb.backoff.Max = 30 * time.Second
+	}
+	if ovndb.backoff.Max < ovndb.backoff.Initial {
+		ovndb.backoff.Max = ovndb.backoff.Initial
+	}
+
+	for table, conds := range cfg.TableConds {
+		ovndb.tableConds[table] = conds
+	}
+	if cfg.SignalCB != nil {
+		ovndb.subscribeImp("", SignalHandler(cfg.SignalCB), cfg.EventQueue, nil)
 	}
 
-	err := connect(ovndb)
+	err := connect(ctx, ovndb)
 	if err != nil {
+		ovndb.cancel()
 		return nil, err
 	}
 	return ovndb, err
 }
 
+// reconnect connects again with increasing delays until it succeeds or the client is closed
 func (c *ovndb) reconnect() {
-	ticker := time.NewTicker(500 * time.Millisecond)
 	go func() {
 		log.Printf("%s disconnected. Reconnecting ... \n", c.addr)
-		retry := 0
-		for range ticker.C {
-			if err := connect(c); err != nil {
-				if retry < 10 {
-					log.Printf("%s reconnect failed (%v). Retry...\n",
-						c.addr, err)
-				} else if retry == 10 {
-					log.Printf("%s reconnect failed (%v). Continue retrying but log will be supressed.\n",
-						c.addr, err)
+		var err error
+		delay := c.backoff.Initial
+		for attempt := 1; ; attempt++ {
+			timer := time.NewTimer(c.backoff.jitter(delay))
+			select {
+			case <-c.done.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+			if c.reconnectingCB != nil {
+				c.reconnectingCB(attempt, err)
+			}
+			if err = connect(c.done, c); err == nil {
+				log.Printf("%s reconnected after %d attempts.\n", c.addr, attempt)
+				if c.reconnectedCB != nil {
+					c.reconnectedCB(attempt)
 				}
-				retry++
-				continue
+				return
+			}
+			if c.done.Err() != nil {
+				return
+			}
+			log.Printf("%s reconnect failed (%v). Retry...\n", c.addr, err)
+			if delay *= 2; delay > c.backoff.Max {
+				delay = c.backoff.Max
 			}
-			log.Printf("%s reconnected after %d retries.\n", c.addr, retry)
-			ticker.Stop()
-			return
 		}
 	}()
 }
 
+// jitter randomizes delay by up to b.Jitter of it
+func (b Backoff) jitter(delay time.Duration) time.Duration {
+	if b.Jitter <= 0 {
+		return delay
+	}
+	jitter := b.Jitter
+	if jitter > 1 {
+		jitter = 1
+	}
+	return delay + time.Duration(jitter*(2*rand.Float64()-1)*float64(delay))
+}
+
+// disconnected updates the state of the client once its connection is gone
+func (c *ovndb) disconnected() {
+	c.connmutex.Lock()
+	c.connected = false
+	closed := c.done.Err() != nil
+	c.connmutex.Unlock()
+	if c.reconn {
+		if !closed {
+			c.reconnect()
+		}
+	} else if c.disconnectCB != nil {
+		c.disconnectCB()
+	}
+}
+
+func (c *ovndb) connectedImp() bool {
+	if c.txn != nil {
+		return c.txn.parent.connectedImp()
+	}
+	c.connmutex.Lock()
+	defer c.connmutex.Unlock()
+	return c.connected
+}
+
 func (c *ovndb) MonitorTables(jsonContext interface{}) (*libovsdb.TableUpdates, error) {
+	updates, _, _, err := c.monitorTables(context.Background(), jsonContext, zeroTxnID)
+	return updates, err
+}
+
+// monitorTables monitors the tables and returns their rows, or only the changes since the
+// transaction lastTxnID if found, when the server keeps them, and the id of the last
+// transaction if the server has transaction ids
+func (c *ovndb) monitorTables(ctx context.Context, jsonContext interface{}, lastTxnID string) (*libovsdb.TableUpdates, bool, string, error) {
 	// get the table list based on the DB
 	var tables []string
 	if c.db == DBNB {
@@ -326,25 +625,63 @@ func (c *ovndb) MonitorTables(jsonContext interface{}) (*libovsdb.TableUpdates,
 		for _, table := range tables {
 			supportedTableMaps[table] = true
 		}
+		if c.db == DBSB {
+			for _, table := range SBTablesOnRequest {
+				supportedTableMaps[table] = true
+			}
+		}
+		schema, _ := c.client.getSchemaCached(c.db)
 		for table, columns := range c.tableCols {
-			if _, ok := supportedTableMaps[table]; ok {
-				// TODO: adding support for specific columns requires more work.
-				// All of the rowTo<TableName>() functions need to be fixed for
-				// the missing columns.
-				if len(columns) != 0 {
-					return nil, fmt.Errorf("providing specific columns is not supported yet")
-				}
-			} else {
-				return nil, fmt.Errorf("specified table %q in database %q not supported by the library",
+			if _, ok := supportedTableMaps[table]; !ok {
+				return nil, false, "", fmt.Errorf("specified table %q in database %q not supported by the library",
 					table, c.db)
 			}
+			for _, column := range columns {
+				if _, ok := schema.Tables[table].Columns[column]; !ok {
+					return nil, false, "", fmt.Errorf("specified column %q of table %q not found in database %q",
+						column, table, c.db)
+				}
+			}
 		}
 	} else {
+		// tables added by later schemas are left out on older servers
+		schema, _ := c.client.getSchemaCached(c.db)
 		c.tableCols = make(map[string][]string)
 		for _, table := range tables {
-			c.tableCols[table] = []string{}
+			if _, ok := schema.Tables[table]; ok {
+				c.tableCols[table] = []string{}
+			}
 		}
 	}
+
+	c.condmutex.Lock()
+	defer c.condmutex.Unlock()
+	condRequests, err := c.monitorCondRequests()
+	if err != nil {
+		return nil, false, "", err
+	}
+	found, txnID, updates, err := c.client.monitorCondSince(ctx, c.db, jsonContext, condRequests, lastTxnID)
+	if err != nil && err.Error() == "unknown method" {
+		// servers older than OVS 2.12 have no transaction ids
+		found, txnID = false, ""
+		updates, err = c.client.monitorCond(ctx, c.db, jsonContext, condRequests)
+	}
+	if err == nil {
+		c.condMonitor = true
+		initial := c.fromTableUpdates2(updates)
+		for table := range c.tableConds {
+			// no row may match the conditions yet, the table is still cached
+			if _, ok := initial.Updates[table]; !ok {
+				initial.Updates[table] = libovsdb.TableUpdate{Rows: make(map[string]libovsdb.RowUpdate)}
+			}
+		}
+		return &initial, found, txnID, nil
+	}
+	if err.Error() != "unknown method" || len(c.tableConds) > 0 {
+		return nil, false, "", err
+	}
+	// servers older than OVS 2.6 only know monitor
+	c.condMonitor = false
 	requests := make(map[string]libovsdb.MonitorRequest)
 	for table, columns := range c.tableCols {
 		requests[table] = libovsdb.MonitorRequest{
@@ -356,12 +693,29 @@ func (c *ovndb) MonitorTables(jsonContext interface{}) (*libovsdb.TableUpdates,
 				Modify:  true,
 			}}
 	}
-	return c.client.Monitor(c.db, jsonContext, requests)
+	initial, err := c.client.monitor(ctx, c.db, jsonContext, requests)
+	return initial, false, "", err
+}
+
+func (c *ovndb) Connected() bool {
+	return c.connectedImp()
 }
 
 // TODO return proper error
 func (c *ovndb) Close() error {
-	c.client.Disconnect()
+	if c.txn != nil {
+		// closing a transaction discards it, the connection belongs to its client
+		c.txn.Rollback()
+		return nil
+	}
+	c.connmutex.Lock()
+	c.cancel()
+	client := c.client
+	c.connmutex.Unlock()
+	if client != nil {
+		client.disconnect()
+	}
+	c.unsubscribeAll()
 	return nil
 }
 
@@ -369,6 +723,90 @@ func (c *ovndb) EncapList(chname string) ([]*Encap, error) {
 	return c.encapListImp(chname)
 }
 
+func (c *ovndb) PortBindingList() ([]*PortBinding, error) {
+	return c.portBindingListImp()
+}
+
+func (c *ovndb) PortBindingGet(lport string) (*PortBinding, error) {
+	return c.portBindingGetImp(lport)
+}
+
+func (c *ovndb) PortBindingListByChassis(chassis string) ([]*PortBinding, error) {
+	return c.portBindingListByChassisImp(chassis)
+}
+
+func (c *ovndb) PortBindingSetChassis(lport, chassis string) (*OvnCommand, error) {
+	return c.portBindingSetChassisImp(lport, chassis)
+}
+
+func (c *ovndb) PortBindingWaitBound(ctx context.Context, lport string) (*PortBinding, error) {
+	return c.portBindingWaitBoundImp(ctx, lport)
+}
+
+func (c *ovndb) DatapathBindingList() ([]*DatapathBinding, error) {
+	return c.datapathBindingListImp()
+}
+
+func (c *ovndb) DatapathBindingGet(uuid string) (*DatapathBinding, error) {
+	return c.datapathBindingGetImp(uuid)
+}
+
+func (c *ovndb) DatapathBindingGetByNB(nbUUID string) (*DatapathBinding, error) {
+	return c.datapathBindingGetByNBImp(nbUUID)
+}
+
+func (c *ovndb) LogicalFlowList(datapath, pipeline string, tableID, priority int, match string) ([]*LogicalFlow, error) {
+	return c.logicalFlowListImp(datapath, pipeline, tableID, priority, match)
+}
+
+func (c *ovndb) MACBindingList() ([]*MACBinding, error) {
+	return c.macBindingListImp()
+}
+
+func (c *ovndb) MACBindingListByPort(lport string) ([]*MACBinding, error) {
+	return c.macBindingListByPortImp(lport)
+}
+
+func (c *ovndb) MACBindingListByDatapath(datapath string) ([]*MACBinding, error) {
+	return c.macBindingListByDatapathImp(datapath)
+}
+
+func (c *ovndb) MACBindingListByIP(ip string) ([]*MACBinding, error) {
+	return c.macBindingListByIPImp(ip)
+}
+
+func (c *ovndb) MACBindingDel(uuid string) (*OvnCommand, error) {
+	return c.macBindingDelImp(uuid)
+}
+
+func (c *ovndb) MACBindingDelOlderThan(age time.Duration) (*OvnCommand, error) {
+	return c.macBindingDelOlderThanImp(age)
+}
+
+func (c *ovndb) MACBindingDelFunc(f func(*MACBinding) bool) (*OvnCommand, error) {
+	return c.macBindingDelFuncImp(f)
+}
+
+func (c *ovndb) FDBList() ([]*FDB, error) {
+	return c.fdbListImp()
+}
+
+func (c *ovndb) FDBListByDatapath(dpKey int) ([]*FDB, error) {
+	return c.fdbListByDatapathImp(dpKey)
+}
+
+func (c *ovndb) FDBListByPort(dpKey, portKey int) ([]*FDB, error) {
+	return c.fdbListByPortImp(dpKey, portKey)
+}
+
+func (c *ovndb) FDBDel(uuid string) (*OvnCommand, error) {
+	return c.fdbDelImp(uuid)
+}
+
+func (c *ovndb) FDBDelFunc(f func(*FDB) bool) (*OvnCommand, error) {
+	return c.fdbDelFuncImp(f)
+}
+
 func (c *ovndb) ChassisGet(name string) ([]*Chassis, error) {
 	return c.chassisGetImp(name)
 }
@@ -382,6 +820,30 @@ func (c *ovndb) ChassisAdd(name string, hostname string, etype []string, ip stri
 	return c.chassisAddImp(name, hostname, etype, ip, external_ids, transport_zones, vtep_lswitches)
 }
 
+func (c *ovndb) ChassisSetHostname(chname string, hostname string) (*OvnCommand, error) {
+	return c.chassisSetHostnameImp(chname, hostname)
+}
+
+func (c *ovndb) ChassisSetExternalIDs(chname string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.chassisSetExternalIDsImp(chname, external_ids)
+}
+
+func (c *ovndb) ChassisSetOtherConfig(chname string, other_config map[string]string) (*OvnCommand, error) {
+	return c.chassisSetOtherConfigImp(chname, other_config)
+}
+
+func (c *ovndb) ChassisSetTransportZones(chname string, transport_zones []string) (*OvnCommand, error) {
+	return c.chassisSetTransportZonesImp(chname, transport_zones)
+}
+
+func (c *ovndb) ChassisSetEncaps(chname string, etype []string, ip string) (*OvnCommand, error) {
+	return c.chassisSetEncapsImp(chname, etype, ip)
+}
+
+func (c *ovndb) ChassisListLagging(maxLag int) ([]*Chassis, error) {
+	return c.chassisListLaggingImp(maxLag)
+}
+
 func (c *ovndb) ChassisDel(name string) (*OvnCommand, error) {
 	return c.chassisDelImp(name)
 }
@@ -470,6 +932,14 @@ func (c *ovndb) LSPGetExternalIds(lsp string) (map[string]string, error) {
 	return c.lspGetExternalIdsImp(lsp)
 }
 
+func (c *ovndb) LSPSetType(lsp string, portType string) (*OvnCommand, error) {
+	return c.lspSetTypeImp(lsp, portType)
+}
+
+func (c *ovndb) LSPSetHAChassisGroup(lsp, group string) (*OvnCommand, error) {
+	return c.lspSetHAChassisGroupImp(lsp, group)
+}
+
 func (c *ovndb) LSLBAdd(ls string, lb string) (*OvnCommand, error) {
 	return c.lslbAddImp(ls, lb)
 }
@@ -506,6 +976,46 @@ func (c *ovndb) LRPList(lr string) ([]*LogicalRouterPort, error) {
 	return c.lrpListImp(lr)
 }
 
+func (c *ovndb) LRPGatewayChassisAdd(lrp, chassisName string, priority int) (*OvnCommand, error) {
+	return c.lrpGatewayChassisAddImp(lrp, chassisName, priority)
+}
+
+func (c *ovndb) LRPGatewayChassisDel(lrp, chassisName string) (*OvnCommand, error) {
+	return c.lrpGatewayChassisDelImp(lrp, chassisName)
+}
+
+func (c *ovndb) LRPGatewayChassisList(lrp string) ([]*GatewayChassis, error) {
+	return c.lrpGatewayChassisListImp(lrp)
+}
+
+func (c *ovndb) LRPSetHAChassisGroup(lrp, group string) (*OvnCommand, error) {
+	return c.lrpSetHAChassisGroupImp(lrp, group)
+}
+
+func (c *ovndb) HAChassisGroupAdd(group string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.haChassisGroupAddImp(group, external_ids)
+}
+
+func (c *ovndb) HAChassisGroupDel(group string) (*OvnCommand, error) {
+	return c.haChassisGroupDelImp(group)
+}
+
+func (c *ovndb) HAChassisGroupGet(group string) (*HAChassisGroup, error) {
+	return c.haChassisGroupGetImp(group)
+}
+
+func (c *ovndb) HAChassisGroupList() ([]*HAChassisGroup, error) {
+	return c.haChassisGroupListImp()
+}
+
+func (c *ovndb) HAChassisGroupAddChassis(group, chassisName string, priority int) (*OvnCommand, error) {
+	return c.haChassisGroupAddChassisImp(group, chassisName, priority)
+}
+
+func (c *ovndb) HAChassisGroupDelChassis(group, chassisName string) (*OvnCommand, error) {
+	return c.haChassisGroupDelChassisImp(group, chassisName)
+}
+
 func (c *ovndb) LRSRAdd(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*OvnCommand, error) {
 	return c.lrsrAddImp(lr, ip_prefix, nexthop, output_port, policy, external_ids)
 }
@@ -522,6 +1032,22 @@ func (c *ovndb) LRSRList(lr string) ([]*LogicalRouterStaticRoute, error) {
 	return c.lrsrListImp(lr)
 }
 
+func (c *ovndb) LRPolicyAdd(lr string, priority int, match, action string, nexthops []string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.lrPolicyAddImp(lr, priority, match, action, nexthops, external_ids)
+}
+
+func (c *ovndb) LRPolicyDel(lr string, priority int, match string) (*OvnCommand, error) {
+	return c.lrPolicyDelImp(lr, priority, match)
+}
+
+func (c *ovndb) LRPolicyDelByUUID(lr, uuid string) (*OvnCommand, error) {
+	return c.lrPolicyDelByUUIDImp(lr, uuid)
+}
+
+func (c *ovndb) LRPolicyList(lr string) ([]*LogicalRouterPolicy, error) {
+	return c.lrPolicyListImp(lr)
+}
+
 func (c *ovndb) LRLBAdd(lr string, lb string) (*OvnCommand, error) {
 	return c.lrlbAddImp(lr, lb)
 }
@@ -586,6 +1112,42 @@ func (c *ovndb) Execute(cmds ...*OvnCommand) error {
 	return c.execute(cmds...)
 }
 
+func (c *ovndb) SetTableConds(table string, where ...interface{}) error {
+	return c.setTableCondsImp(context.Background(), table, where)
+}
+
+func (c *ovndb) Subscribe(table string, handler EventHandler, predicates ...EventPredicate) *Subscription {
+	return c.subscribeImp(table, handler, c.eventQueue, predicates)
+}
+
+func (c *ovndb) SubscribeQueue(table string, handler EventHandler, queue QueueConfig, predicates ...EventPredicate) *Subscription {
+	return c.subscribeImp(table, handler, queue, predicates)
+}
+
+func (c *ovndb) SetTableCondsContext(ctx context.Context, table string, where ...interface{}) error {
+	return c.setTableCondsImp(ctx, table, where)
+}
+
+func (c *ovndb) ExecuteContext(ctx context.Context, cmds ...*OvnCommand) error {
+	return c.executeContext(ctx, cmds...)
+}
+
+func (c *ovndb) ExecuteWait(ctx context.Context, wait WaitFor, cmds ...*OvnCommand) error {
+	return c.executeWaitImp(ctx, wait, cmds...)
+}
+
+func (c *ovndb) NewTransaction() Transaction {
+	return c.newTransactionImp()
+}
+
+func (c *ovndb) Transact(fn func(txn Transaction) error) error {
+	return c.transactImp(context.Background(), fn)
+}
+
+func (c *ovndb) TransactContext(ctx context.Context, fn func(txn Transaction) error) error {
+	return c.transactImp(ctx, fn)
+}
+
 func (c *ovndb) LSGet(ls string) ([]*LogicalSwitch, error) {
 	return c.lsGetImp(ls)
 }
@@ -634,6 +1196,38 @@ func (c *ovndb) DHCPOptionsList() ([]*DHCPOptions, error) {
 	return c.dhcpOptionsListImp()
 }
 
+func (c *ovndb) DNSAdd(records map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.dnsAddImp(records, external_ids)
+}
+
+func (c *ovndb) DNSSet(uuid string, records map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	return c.dnsSetImp(uuid, records, external_ids)
+}
+
+func (c *ovndb) DNSDel(uuid string) (*OvnCommand, error) {
+	return c.dnsDelImp(uuid)
+}
+
+func (c *ovndb) DNSGet(uuid string) (*DNS, error) {
+	return c.dnsGetImp(uuid)
+}
+
+func (c *ovndb) DNSList() ([]*DNS, error) {
+	return c.dnsListImp()
+}
+
+func (c *ovndb) LSDNSAdd(ls string, uuid string) (*OvnCommand, error) {
+	return c.lsDNSAddImp(ls, uuid)
+}
+
+func (c *ovndb) LSDNSDel(ls string, uuid string) (*OvnCommand, error) {
+	return c.lsDNSDelImp(ls, uuid)
+}
+
+func (c *ovndb) LSDNSList(ls string) ([]*DNS, error) {
+	return c.lsDNSListImp(ls)
+}
+
 func (c *ovndb) LRNATAdd(lr string, ntype string, externalIp string, logicalIp string, external_ids map[string]string, logicalPortAndExternalMac ...string) (*OvnCommand, error) {
 	return c.lrNatAddImp(lr, ntype, externalIp, logicalIp, external_ids, logicalPortAndExternalMac...)
 }
@@ -678,6 +1272,30 @@ func (c *ovndb) SBGlobalGetOptions() (map[string]string, error) {
 	return c.sbGlobalGetOptionsImp()
 }
 
+func (c *ovndb) ConnectionSet(conns ...*Connection) (*OvnCommand, error) {
+	return c.connectionSetImp(conns...)
+}
+
+func (c *ovndb) ConnectionDel() (*OvnCommand, error) {
+	return c.connectionDelImp()
+}
+
+func (c *ovndb) ConnectionList() ([]*Connection, error) {
+	return c.connectionListImp()
+}
+
+func (c *ovndb) SSLSet(ssl *SSLConfig) (*OvnCommand, error) {
+	return c.sslSetImp(ssl)
+}
+
+func (c *ovndb) SSLDel() (*OvnCommand, error) {
+	return c.sslDelImp()
+}
+
+func (c *ovndb) SSLGet() (*SSLConfig, error) {
+	return c.sslGetImp()
+}
+
 func (c *ovndb) PortGroupAdd(group string, ports []string, external_ids map[string]string) (*OvnCommand, error) {
 	return c.pgAddImp(group, ports, external_ids)
 }
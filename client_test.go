@@ -1,8 +1,18 @@
 package goovn
 
 import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
+	ovsdbtest "github.com/ebay/go-ovn/testing"
+	"github.com/ebay/libovsdb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -84,11 +94,13 @@ func TestNewClient_ValidNBTableCols(t *testing.T) {
 	// Create a Logical Router and we should not be able to list the LR since
 	// we didn't express interest in the Logical_Router table
 	t.Logf("Adding LR %s", LR)
-	cmd, err = api.LRAdd(LR, nil)
+	_, err = api.LRAdd(LR, nil)
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+	cmd, err = ovndbapi.LRAdd(LR, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = api.Execute(cmd)
+	err = ovndbapi.Execute(cmd)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -96,7 +108,7 @@ func TestNewClient_ValidNBTableCols(t *testing.T) {
 	t.Logf("Listing LR %s", LR)
 	// We will not get the LR since we are not monitoring it.
 	_, err = api.LRList()
-	assert.Equal(t, err, ErrorNotFound)
+	assert.True(t, isError(err, ErrorNotMonitored), err)
 
 	// We cannot delete the LR since the client doesn't have the info for it.
 	_ = api.Close()
@@ -122,6 +134,26 @@ func TestNewClient_ValidNBTableCols(t *testing.T) {
 	t.Logf("Deleting LR %s Done", LR)
 }
 
+func TestNewClient_TableNotMonitored(t *testing.T) {
+	cfg := buildOvnDbConfig(DBNB)
+	cfg.TableCols = map[string][]string{
+		TableLogicalSwitchPort: {},
+	}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+
+	// the switch of the port cannot be looked up without Logical_Switch
+	_, err = api.LSPAdd(LSW, LSP)
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+	_, err = api.LSList()
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+	_, err = api.LSPList(LSW)
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+}
+
 func TestNewClient_InvalidSBTables(t *testing.T) {
 	cfg := buildOvnDbConfig(DBSB)
 	cfg.TableCols = map[string][]string{
@@ -141,3 +173,610 @@ func TestNewClient_ValidSBTableInvalidCol(t *testing.T) {
 	assert.Error(t, err)
 	t.Log(err.Error())
 }
+
+func TestNewClientContext_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewClientContext(ctx, buildOvnDbConfig(DBNB))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestExecuteContext_Canceled(t *testing.T) {
+	api := getOVNClient(DBNB)
+	defer api.Close()
+
+	cmd, err := api.LSAdd(LS3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, api.ExecuteContext(ctx, cmd))
+
+	// the command was not sent
+	_, err = api.LSGet(LS3)
+	assert.Equal(t, ErrorNotFound, err)
+	assert.Nil(t, api.ExecuteContext(context.Background()))
+}
+
+func TestNewClient_NBTableCols(t *testing.T) {
+	cfg := buildOvnDbConfig(DBNB)
+	cfg.TableCols = map[string][]string{
+		TableLogicalSwitch:     {"name", "ports"},
+		TableLogicalSwitchPort: {"name", "external_ids"},
+	}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err := ovndbapi.LSAdd(LS3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSPAdd(LS3, LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSPSetAddress(LSP, ADDR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.LSDel(LS3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// only the monitored columns are filled in, once api got the update
+	var lsps []*LogicalSwitchPort
+	assert.Eventually(t, func() bool {
+		lsps, err = api.LSPList(LS3)
+		return err == nil && len(lsps) == 1
+	}, time.Second, 10*time.Millisecond)
+	if len(lsps) != 1 {
+		t.FailNow()
+	}
+	assert.Equal(t, LSP, lsps[0].Name)
+	assert.Nil(t, lsps[0].Addresses)
+
+	// APIs looking up columns that are not monitored fail
+	_, err = api.ACLList(LS3)
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+	_, err = api.LSPSetAddress(LSP, ADDR)
+	assert.Nil(t, err)
+	_, err = api.LSLBList(LS3)
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+}
+
+func TestSetTableConds(t *testing.T) {
+	cfg := buildOvnDbConfig(DBNB)
+	cfg.TableCols = map[string][]string{
+		TableLogicalSwitchPort: {"name", "external_ids"},
+	}
+	cfg.TableConds = map[string][]interface{}{
+		TableLogicalSwitchPort: {libovsdb.NewCondition("external_ids", "includes",
+			libovsdb.OvsMap{GoMap: map[interface{}]interface{}{"node": "a"}})},
+	}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err := ovndbapi.LSAdd(LS3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSPAdd(LS3, LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSPAdd(LS3, LSP_SECOND)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.LSDel(LS3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// ports enter the monitor as they start matching the conditions
+	_, err = api.LSPGet(LSP)
+	assert.Equal(t, ErrorNotFound, err)
+	cmd, err = ovndbapi.LSPSetExternalIds(LSP, map[string]string{"node": "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var lsp *LogicalSwitchPort
+	assert.Eventually(t, func() bool {
+		lsp, err = api.LSPGet(LSP)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	if lsp == nil {
+		t.FailNow()
+	}
+	assert.Equal(t, "a", lsp.ExternalID["node"])
+	_, err = api.LSPGet(LSP_SECOND)
+	assert.Equal(t, ErrorNotFound, err)
+
+	// the conditions can be changed while connected
+	err = api.SetTableConds(TableLogicalSwitchPort, libovsdb.NewCondition("name", "==", LSP_SECOND))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = api.LSPGet(LSP)
+	assert.Equal(t, ErrorNotFound, err)
+	_, err = api.LSPGet(LSP_SECOND)
+	assert.Nil(t, err)
+
+	// no conditions monitor all the rows
+	err = api.SetTableConds(TableLogicalSwitchPort)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = api.LSPGet(LSP)
+	assert.Nil(t, err)
+	_, err = api.LSPGet(LSP_SECOND)
+	assert.Nil(t, err)
+}
+
+// newTestServer returns an in-memory OVSDB server listening on socket
+func newTestServer(t *testing.T, socket string) *ovsdbtest.Server {
+	srv, err := ovsdbtest.NewServer(ovsdbtest.NBSchema, ovsdbtest.SBSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.Listen("unix", socket); err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+// schemaWithout returns schema without tables, as served by older servers
+func schemaWithout(t *testing.T, schema string, tables ...string) string {
+	var j map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &j); err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range tables {
+		delete(j["tables"].(map[string]interface{}), table)
+	}
+	b, err := json.Marshal(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestReconnect(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNNB_SOCKET)
+	srv := newTestServer(t, socket)
+
+	var mutex sync.Mutex
+	var errs []error
+	reconnecting := func() []error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]error{}, errs...)
+	}
+	reconnected := make(chan int, 1)
+	cfg := &Config{
+		Db:               DBNB,
+		Addr:             UNIX + ":" + socket,
+		Reconnect:        true,
+		ReconnectBackoff: Backoff{Initial: 10 * time.Millisecond, Max: 40 * time.Millisecond, Jitter: 0.5},
+		OnReconnecting: func(attempt int, err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			errs = append(errs, err)
+		},
+		OnReconnected: func(attempts int) {
+			reconnected <- attempts
+		},
+	}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, api.Connected())
+
+	srv.Close()
+	assert.Eventually(t, func() bool {
+		return !api.Connected() && len(reconnecting()) >= 2
+	}, time.Second, 10*time.Millisecond)
+	first := reconnecting()
+	assert.Nil(t, first[0])
+	assert.NotNil(t, first[1])
+
+	srv = newTestServer(t, socket)
+	select {
+	case attempts := <-reconnected:
+		assert.True(t, attempts >= 2, attempts)
+	case <-time.After(time.Second):
+		t.Fatal("not reconnected")
+	}
+	assert.True(t, api.Connected())
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ls, err := api.LSGet(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(ls))
+
+	// Close stops reconnecting
+	srv.Close()
+	assert.Eventually(t, func() bool {
+		return !api.Connected()
+	}, time.Second, 10*time.Millisecond)
+	api.Close()
+	attempts := len(reconnecting())
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, attempts, len(reconnecting()))
+	assert.False(t, api.Connected())
+}
+
+func TestNotConnected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNNB_SOCKET)
+	srv := newTestServer(t, socket)
+
+	failed := make(chan struct{}, 1)
+	cfg := &Config{
+		Db:               DBNB,
+		Addr:             UNIX + ":" + socket,
+		Reconnect:        true,
+		ReconnectBackoff: Backoff{Initial: 10 * time.Millisecond, Max: time.Minute},
+		OnReconnecting: func(attempt int, err error) {
+			if err != nil {
+				select {
+				case failed <- struct{}{}:
+				default:
+				}
+			}
+		},
+	}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv.Close()
+	select {
+	case <-failed:
+	case <-time.After(time.Second):
+		t.Fatal("no failed reconnect")
+	}
+	err = api.Execute(cmd)
+	assert.True(t, isError(err, ErrorNotConnected), err)
+	err = api.SetTableCondsContext(context.Background(), TableLogicalSwitch)
+	assert.True(t, isError(err, ErrorNotConnected), err)
+}
+
+func TestReconnectResync(t *testing.T) {
+	t.Run("monitor_cond_since", func(t *testing.T) {
+		testReconnectResync(t)
+	})
+	t.Run("monitor_cond", func(t *testing.T) {
+		testReconnectResync(t, "monitor_cond_since")
+	})
+}
+
+// testReconnectResync checks the cache after the database changes while disconnected
+// from a server on which the given methods are disabled
+func testReconnectResync(t *testing.T, disabled ...string) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNNB_SOCKET)
+	srv := newTestServer(t, socket)
+	defer srv.Close()
+	srv.Disable(disabled...)
+
+	execute := func(api Client, newCmd func(api Client) (*OvnCommand, error)) error {
+		cmd, err := newCmd(api)
+		if err != nil {
+			return err
+		}
+		return api.Execute(cmd)
+	}
+	cfg := &Config{Db: DBNB, Addr: UNIX + ":" + socket}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ls := range []string{"ls-deleted", "ls-updated", "ls-unchanged"} {
+		if err = execute(api, func(api Client) (*OvnCommand, error) { return api.LSAdd(ls) }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	api.Close()
+
+	// the database changes while the client is disconnected, before it reconnects
+	changed := make(chan error, 1)
+	reconnected := make(chan int, 1)
+	cfg.Reconnect = true
+	cfg.ReconnectBackoff = Backoff{Initial: 10 * time.Millisecond}
+	cfg.OnReconnecting = func(attempt int, err error) {
+		if attempt > 1 {
+			return
+		}
+		peer, err := NewClient(&Config{Db: DBNB, Addr: UNIX + ":" + socket})
+		if err != nil {
+			changed <- err
+			return
+		}
+		defer peer.Close()
+		for _, newCmd := range []func(api Client) (*OvnCommand, error){
+			func(api Client) (*OvnCommand, error) { return api.LSDel("ls-deleted") },
+			func(api Client) (*OvnCommand, error) {
+				return api.LSExtIdsAdd("ls-updated", map[string]string{"foo": "bar"})
+			},
+			func(api Client) (*OvnCommand, error) { return api.LSAdd("ls-created") },
+		} {
+			if err = execute(peer, newCmd); err != nil {
+				break
+			}
+		}
+		changed <- err
+	}
+	cfg.OnReconnected = func(attempts int) {
+		reconnected <- attempts
+	}
+	api, err = NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	switches := &eventRecorder{}
+	api.Subscribe(TableLogicalSwitch, switches.handle)
+	lastTxnID := api.(*ovndb).lastTxn()
+	assert.Equal(t, len(disabled) > 0, lastTxnID == zeroTxnID)
+
+	srv.Disconnect()
+	select {
+	case err = <-changed:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("not reconnecting")
+	}
+	select {
+	case <-reconnected:
+	case <-time.After(time.Second):
+		t.Fatal("not reconnected")
+	}
+
+	events := make(map[string]Event)
+	for _, event := range switches.wait(t, 3) {
+		if event.Type == EventDelete {
+			events[event.Old.(*LogicalSwitch).Name] = event
+		} else {
+			events[event.New.(*LogicalSwitch).Name] = event
+		}
+	}
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, EventDelete, events["ls-deleted"].Type)
+	assert.Equal(t, EventCreate, events["ls-created"].Type)
+	assert.Equal(t, EventUpdate, events["ls-updated"].Type)
+	assert.Equal(t, "bar", events["ls-updated"].New.(*LogicalSwitch).ExternalID["foo"])
+
+	lss, err := api.LSList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, ls := range lss {
+		names = append(names, ls.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"ls-created", "ls-unchanged", "ls-updated"}, names)
+	if len(disabled) > 0 {
+		return
+	}
+	// only the changes are sent since the transaction applied before disconnecting
+	updates, found, _, err := api.(*ovndb).monitorTables(context.Background(), "since", lastTxnID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, found)
+	assert.Equal(t, 3, len(updates.Updates[TableLogicalSwitch].Rows))
+	assert.NotEqual(t, lastTxnID, api.(*ovndb).lastTxn())
+}
+
+// notificationRecorder records the notifications of a connection
+type notificationRecorder struct {
+	mutex         sync.Mutex
+	notifications []string
+}
+
+func (r *notificationRecorder) record(notification string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.notifications = append(r.notifications, notification)
+}
+
+func (r *notificationRecorder) recorded() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]string{}, r.notifications...)
+}
+
+func (r *notificationRecorder) Update(context interface{}, tableUpdates libovsdb.TableUpdates) {
+	r.record("update")
+}
+func (r *notificationRecorder) Locked([]interface{}) {}
+func (r *notificationRecorder) Stolen([]interface{}) {}
+func (r *notificationRecorder) Echo([]interface{})   {}
+func (r *notificationRecorder) Disconnected(client *libovsdb.OvsdbClient) {
+	r.record("disconnected")
+}
+
+func TestNotificationsHeld(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNNB_SOCKET)
+	srv := newTestServer(t, socket)
+	defer srv.Close()
+
+	ctx := context.Background()
+	ovsdb, err := dial(ctx, UNIX+":"+socket, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ovsdb.disconnect()
+	notifier := &notificationRecorder{}
+	ovsdb.register(notifier)
+	if _, err = ovsdb.getSchema(ctx, DBNB); err != nil {
+		t.Fatal(err)
+	}
+	requests := map[string]libovsdb.MonitorRequest{TableLogicalSwitch: {
+		Select: libovsdb.MonitorSelect{Initial: true, Insert: true, Delete: true, Modify: true}}}
+	if _, err = ovsdb.monitor(ctx, DBNB, "", requests); err != nil {
+		t.Fatal(err)
+	}
+	held := func() int {
+		ovsdb.mutex.Lock()
+		defer ovsdb.mutex.Unlock()
+		return len(ovsdb.held)
+	}
+
+	// an update and a drop before the initial rows are applied
+	peer, err := NewClient(&Config{Db: DBNB, Addr: UNIX + ":" + socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer peer.Close()
+	cmd, err := peer.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = peer.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	assert.Eventually(t, func() bool { return held() == 1 }, time.Second, 10*time.Millisecond)
+	srv.Disconnect()
+	assert.Eventually(t, func() bool { return held() == 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, len(notifier.recorded()))
+
+	ovsdb.release()
+	assert.Equal(t, []string{"update", "disconnected"}, notifier.recorded())
+}
+
+func TestInactivityProbe(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNNB_SOCKET)
+	srv := newTestServer(t, socket)
+	defer srv.Close()
+
+	disconnected := make(chan struct{}, 1)
+	api, err := NewClient(&Config{
+		Db:              DBNB,
+		Addr:            UNIX + ":" + socket,
+		InactivityProbe: 20 * time.Millisecond,
+		DisconnectCB: func() {
+			disconnected <- struct{}{}
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+
+	// echo requests are answered
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, api.Connected())
+
+	// a handler holding up the monitor updates is not inactivity of the server
+	sub := api.SubscribeQueue(TableLogicalSwitch, func(Event) {
+		time.Sleep(100 * time.Millisecond)
+	}, QueueConfig{Size: 1, Overflow: OverflowBlock})
+	for _, name := range []string{"ls-probe1", "ls-probe2", "ls-probe3"} {
+		cmd, err := api.LSAdd(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = api.Execute(cmd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sub.Unsubscribe()
+	select {
+	case <-disconnected:
+		t.Fatal("disconnected")
+	case <-time.After(100 * time.Millisecond):
+	}
+	assert.True(t, api.Connected())
+
+	thaw := srv.Freeze()
+	defer thaw()
+	select {
+	case <-disconnected:
+	case <-time.After(time.Second):
+		t.Fatal("not disconnected")
+	}
+	assert.False(t, api.Connected())
+}
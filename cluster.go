@@ -0,0 +1,224 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ebay/libovsdb"
+)
+
+const (
+	// dbServer is the database in which ovsdb-server describes its other databases,
+	// it is monitored with itself as json-value, see ovsdb-server(5)
+	dbServer            = "_Server"
+	tableServerDatabase = "Database"
+)
+
+// serverDatabase is the state of a database in the _Server database
+type serverDatabase struct {
+	clustered bool
+	connected bool
+	leader    bool
+	index     int
+}
+
+func rowToServerDatabase(row libovsdb.Row) serverDatabase {
+	model, _ := row.Fields["model"].(string)
+	connected, _ := row.Fields["connected"].(bool)
+	leader, _ := row.Fields["leader"].(bool)
+	state := serverDatabase{
+		clustered: model == "clustered",
+		connected: connected,
+		leader:    leader,
+	}
+	// the raft index is an empty set for standalone databases
+	switch index := row.Fields["index"].(type) {
+	case float64:
+		state.index = int(index)
+	case int:
+		state.index = index
+	}
+	return state
+}
+
+// monitorServer monitors the _Server database of ovsdb and returns the state of db in it
+func monitorServer(ctx context.Context, ovsdb *ovsdbClient, db string) (*serverDatabase, error) {
+	requests := map[string]libovsdb.MonitorRequest{
+		tableServerDatabase: {
+			Columns: []string{"name", "model", "connected", "leader", "index"},
+			Select: libovsdb.MonitorSelect{
+				Initial: true,
+				Insert:  true,
+				Delete:  true,
+				Modify:  true,
+			}},
+	}
+	updates, err := ovsdb.monitor(ctx, dbServer, dbServer, requests)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range updates.Updates[tableServerDatabase].Rows {
+		if name, _ := row.New.Fields["name"].(string); name == db {
+			state := rowToServerDatabase(row.New)
+			return &state, nil
+		}
+	}
+	return nil, fmt.Errorf("database %s not found in %s", db, dbServer)
+}
+
+// checkMember returns why a server whose database is in state is refused, if it is. A
+// member of a cluster must be connected to it and have a log at least at minIndex, the
+// highest index seen, so that the client never goes back in time.
+func (c *ovndb) checkMember(state *serverDatabase, minIndex int) error {
+	if state == nil || !state.clustered {
+		return nil
+	}
+	if !state.connected {
+		return fmt.Errorf("%s is not connected to its cluster", c.db)
+	}
+	if state.index < minIndex {
+		return fmt.Errorf("%s is stale, at index %d instead of %d", c.db, state.index, minIndex)
+	}
+	if c.leaderOnly && !state.leader {
+		return fmt.Errorf("%s is not the leader of its cluster", c.db)
+	}
+	return nil
+}
+
+// connectRemote connects to remote and checks that it is a suitable member of the cluster
+func (c *ovndb) connectRemote(ctx context.Context, remote string) (*ovsdbClient, *serverDatabase, error) {
+	ovsdb, err := dial(ctx, remote, c.tlsConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	// from the start, so that no update of _Server is lost, held until connected
+	ovsdb.register(ovnNotifier{c})
+	dbs, err := ovsdb.listDbs(ctx)
+	if err != nil {
+		ovsdb.disconnect()
+		return nil, nil, err
+	}
+	found, clustered := false, false
+	for _, db := range dbs {
+		found = found || db == c.db
+		// servers before OVS 2.9 have no _Server database
+		clustered = clustered || db == dbServer
+	}
+	if !found {
+		ovsdb.disconnect()
+		return nil, nil, fmt.Errorf("database %s not found on %s", c.db, remote)
+	}
+	if !clustered {
+		return ovsdb, nil, nil
+	}
+	state, err := monitorServer(ctx, ovsdb, c.db)
+	if err == nil {
+		c.connmutex.Lock()
+		minIndex := c.serverIndex
+		c.connmutex.Unlock()
+		err = c.checkMember(state, minIndex)
+	}
+	if err != nil {
+		ovsdb.disconnect()
+		return nil, nil, fmt.Errorf("%s refused: %s", remote, err.Error())
+	}
+	return ovsdb, state, nil
+}
+
+// connectMember connects to a member of the cluster whose remotes are the comma separated
+// list c.addr, preferring the leader. Followers are used only if no leader is reachable
+// and c.leaderOnly is false. Remotes are tried from the one after the member connected
+// last, so that a disconnect fails over to another member.
+func (c *ovndb) connectMember(ctx context.Context) (*ovsdbClient, error) {
+	remotes := strings.Split(c.addr, ",")
+	var follower *ovsdbClient
+	var followerState *serverDatabase
+	var followerRemote int
+	var err error
+	for i := range remotes {
+		remote := (c.nextRemote + i) % len(remotes)
+		var ovsdb *ovsdbClient
+		var state *serverDatabase
+		ovsdb, state, err = c.connectRemote(ctx, strings.TrimSpace(remotes[remote]))
+		if err != nil {
+			if ctx.Err() != nil {
+				if follower != nil {
+					follower.disconnect()
+				}
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		if state == nil || !state.clustered || state.leader {
+			if follower != nil {
+				follower.disconnect()
+			}
+			c.connectedMember(remote, state)
+			return ovsdb, nil
+		}
+		if follower == nil {
+			follower, followerState, followerRemote = ovsdb, state, remote
+		} else {
+			ovsdb.disconnect()
+		}
+	}
+	if follower != nil {
+		c.connectedMember(followerRemote, followerState)
+		return follower, nil
+	}
+	return nil, err
+}
+
+// connectedMember records the remote connected to and the index of its database
+func (c *ovndb) connectedMember(remote int, state *serverDatabase) {
+	c.nextRemote = remote + 1
+	if state == nil {
+		return
+	}
+	c.connmutex.Lock()
+	defer c.connmutex.Unlock()
+	if state.index > c.serverIndex {
+		c.serverIndex = state.index
+	}
+}
+
+// serverUpdate handles the updates of the _Server database, the client disconnects, and
+// so fails over to another member if it reconnects, once its member is refused
+func (c *ovndb) serverUpdate(updates libovsdb.TableUpdates) {
+	for _, row := range updates.Updates[tableServerDatabase].Rows {
+		if name, _ := row.New.Fields["name"].(string); name != c.db {
+			continue
+		}
+		state := rowToServerDatabase(row.New)
+		c.connmutex.Lock()
+		minIndex := c.serverIndex
+		if state.index > c.serverIndex {
+			c.serverIndex = state.index
+		}
+		client := c.client
+		c.connmutex.Unlock()
+		if err := c.checkMember(&state, minIndex); err != nil && client != nil {
+			log.Printf("%s: %v, disconnecting\n", c.addr, err)
+			// the notification is handled by the read loop of the connection
+			go client.disconnect()
+		}
+	}
+}
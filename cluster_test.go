@@ -0,0 +1,148 @@
+package goovn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	ovsdbtest "github.com/ebay/go-ovn/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCluster starts n servers, each with a logical switch named after it, and returns
+// them with their remotes
+func newTestCluster(t *testing.T, dir string, n int) ([]*ovsdbtest.Server, []string) {
+	var servers []*ovsdbtest.Server
+	var remotes []string
+	for i := 0; i < n; i++ {
+		socket := filepath.Join(dir, "nb"+string(rune('0'+i))+".ovsdb")
+		srv := newTestServer(t, socket)
+		remote := UNIX + ":" + socket
+		api, err := NewClient(&Config{Db: DBNB, Addr: remote})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cmd, err := api.LSAdd("member" + string(rune('0'+i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = api.Execute(cmd); err != nil {
+			t.Fatal(err)
+		}
+		api.Close()
+		servers = append(servers, srv)
+		remotes = append(remotes, remote)
+	}
+	return servers, remotes
+}
+
+// hasMember returns whether api has the logical switch of member in its cache
+func hasMember(api Client, member string) bool {
+	lss, err := api.LSGet(member)
+	return err == nil && len(lss) == 1
+}
+
+func setClusterState(t *testing.T, srv *ovsdbtest.Server, connected, leader bool, index int) {
+	if err := srv.SetClusterState(DBNB, connected, leader, index); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClusterFailover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	servers, remotes := newTestCluster(t, dir, 3)
+	defer func() {
+		for _, srv := range servers {
+			srv.Close()
+		}
+	}()
+	setClusterState(t, servers[0], true, false, 5)
+	setClusterState(t, servers[1], true, true, 5)
+	setClusterState(t, servers[2], false, false, 5)
+
+	api, err := NewClient(&Config{
+		Db:               DBNB,
+		Addr:             strings.Join(remotes, ","),
+		Reconnect:        true,
+		ReconnectBackoff: Backoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	// the leader is preferred over the first follower
+	assert.True(t, hasMember(api, "member1"))
+	assert.False(t, hasMember(api, "member0"))
+
+	// the member disconnected from the cluster is refused, the follower is used
+	servers[1].Close()
+	assert.Eventually(t, func() bool {
+		return api.Connected() && hasMember(api, "member0")
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, hasMember(api, "member1"))
+
+	// the member behind the index seen is stale
+	setClusterState(t, servers[2], true, true, 3)
+	servers[0].Close()
+	assert.Eventually(t, func() bool {
+		return !api.Connected()
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	assert.False(t, api.Connected())
+
+	setClusterState(t, servers[2], true, true, 7)
+	assert.Eventually(t, func() bool {
+		return api.Connected() && hasMember(api, "member2")
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, hasMember(api, "member0"))
+}
+
+func TestClusterLeaderOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	servers, remotes := newTestCluster(t, dir, 2)
+	defer func() {
+		for _, srv := range servers {
+			srv.Close()
+		}
+	}()
+	setClusterState(t, servers[0], true, false, 5)
+	setClusterState(t, servers[1], false, false, 5)
+
+	cfg := &Config{
+		Db:               DBNB,
+		Addr:             strings.Join(remotes, ","),
+		LeaderOnly:       true,
+		Reconnect:        true,
+		ReconnectBackoff: Backoff{Initial: 10 * time.Millisecond, Max: 20 * time.Millisecond},
+	}
+	_, err = NewClient(cfg)
+	assert.NotNil(t, err)
+
+	setClusterState(t, servers[1], true, true, 6)
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	assert.True(t, hasMember(api, "member1"))
+	assert.False(t, hasMember(api, "member0"))
+
+	// the client leaves a member once it loses the leadership
+	setClusterState(t, servers[1], true, false, 7)
+	setClusterState(t, servers[0], true, true, 7)
+	assert.Eventually(t, func() bool {
+		return api.Connected() && hasMember(api, "member0")
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, hasMember(api, "member1"))
+}
@@ -22,6 +22,7 @@ const (
 	opDelete string = "delete"
 	opSelect string = "select"
 	opUpdate string = "update"
+	opWait   string = "wait"
 )
 
 const (
@@ -43,14 +44,24 @@ const (
 	TableMeterBand                string = "Meter_Band"
 	TableLogicalRouterPort        string = "Logical_Router_Port"
 	TableLogicalRouterStaticRoute string = "Logical_Router_Static_Route"
+	TableLogicalRouterPolicy      string = "Logical_Router_Policy"
 	TableNAT                      string = "NAT"
 	TableDHCPOptions              string = "DHCP_Options"
 	TableConnection               string = "Connection"
 	TableDNS                      string = "DNS"
 	TableSSL                      string = "SSL"
 	TableGatewayChassis           string = "Gateway_Chassis"
+	TableHAChassis                string = "HA_Chassis"
+	TableHAChassisGroup           string = "HA_Chassis_Group"
 	TableChassis                  string = "Chassis"
+	TableChassisPrivate           string = "Chassis_Private"
 	TableEncap                    string = "Encap"
+	TableDatapathBinding          string = "Datapath_Binding"
+	TablePortBinding              string = "Port_Binding"
+	TableLogicalFlow              string = "Logical_Flow"
+	TableLogicalDPGroup           string = "Logical_DP_Group"
+	TableMACBinding               string = "MAC_Binding"
+	TableFDB                      string = "FDB"
 	TableSBGlobal                 string = "SB_Global"
 )
 
@@ -63,8 +74,11 @@ var NBTablesOrder = []string{
 	TableQoS,
 	TableMeter,
 	TableMeterBand,
+	TableHAChassis,
+	TableHAChassisGroup,
 	TableLogicalRouterPort,
 	TableLogicalRouterStaticRoute,
+	TableLogicalRouterPolicy,
 	TableLogicalSwitchPort,
 	TableNAT,
 	TableConnection,
@@ -78,6 +92,20 @@ var NBTablesOrder = []string{
 
 var SBTablesOrder = []string{
 	TableChassis,
+	TableChassisPrivate,
 	TableEncap,
+	TablePortBinding,
+	TableMACBinding,
+	TableFDB,
+	TableConnection,
+	TableSSL,
 	TableSBGlobal,
 }
+
+// SBTablesOnRequest are supported but only monitored when listed in Config.TableCols,
+// they grow with the size of the deployment
+var SBTablesOnRequest = []string{
+	TableDatapathBinding,
+	TableLogicalFlow,
+	TableLogicalDPGroup,
+}
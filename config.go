@@ -18,15 +18,32 @@ package goovn
 
 import (
 	"crypto/tls"
+	"time"
 )
 
+// Backoff of reconnection attempts, the delay before each attempt starts at Initial
+// and doubles up to Max, each delay is randomized by up to Jitter of itself
+type Backoff struct {
+	Initial time.Duration // 500ms if unset
+	Max     time.Duration // 30s if unset
+	Jitter  float64       // From 0, no randomization, to 1
+}
+
 // Config ovn nb and sb db client config
 type Config struct {
-	Db           string
-	Addr         string
-	TLSConfig    *tls.Config
-	SignalCB     OVNSignal
-	DisconnectCB OVNDisconnectedCallback // Callback that is called when disconnected, if "Reconnect" is false.
-	Reconnect    bool                    // Automatically reconnect when disconnected
-	TableCols    map[string][]string     // List of tables and their cols to be monitored
+	Db               string
+	Addr             string // Comma separated remotes, e.g. the members of a cluster, the leader is preferred
+	TLSConfig        *tls.Config
+	SignalCB         OVNSignal                // Subscribed to the events of all tables, see SignalHandler
+	DisconnectCB     OVNDisconnectedCallback  // Callback that is called when disconnected, if "Reconnect" is false.
+	Reconnect        bool                     // Automatically reconnect when disconnected, until Close
+	ReconnectBackoff Backoff                  // Delays between reconnection attempts
+	OnReconnecting   OVNReconnectingCallback  // Callback that is called before each reconnection attempt
+	OnReconnected    OVNReconnectedCallback   // Callback that is called once reconnected
+	InactivityProbe  time.Duration            // Time without traffic before an echo request, the connection is closed if nothing is received within it either. Disabled if 0
+	LeaderOnly       bool                     // Connect only to the leader of a clustered database, never to a follower
+	TableCols        map[string][]string      // List of tables and their cols to be monitored, all cols if none. APIs needing other tables or cols fail with ErrorNotMonitored. Needed for SBTablesOnRequest
+	TableConds       map[string][]interface{} // Conditions on the rows monitored per table, see Client.SetTableConds
+	VerifyCache      bool                     // Fail Execute with ErrorConflict if rows changed since validated against the cache
+	EventQueue       QueueConfig              // Queue of SignalCB and of Client.Subscribe, not bounded by default
 }
@@ -0,0 +1,327 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"sort"
+
+	"github.com/ebay/libovsdb"
+)
+
+// Connection is a remote the ovsdb-server of the client listens on or connects to,
+// InactivityProbe and MaxBackoff are nil when unset, Role and ReadOnly are for DBSB only
+type Connection struct {
+	UUID            string
+	Target          string
+	InactivityProbe *int
+	MaxBackoff      *int
+	Role            string
+	ReadOnly        bool
+	OtherConfig     map[interface{}]interface{}
+	ExternalID      map[interface{}]interface{}
+	IsConnected     bool
+	Status          map[interface{}]interface{}
+}
+
+// SSLConfig is the SSL configuration of the ovsdb-server of the client
+type SSLConfig struct {
+	UUID            string
+	PrivateKey      string
+	Certificate     string
+	CACert          string
+	BootstrapCACert bool
+	SSLProtocols    string
+	SSLCiphers      string
+	ExternalID      map[interface{}]interface{}
+}
+
+// globalTable returns the table holding the connections and ssl of the database
+func (odbi *ovndb) globalTable() string {
+	if odbi.db == DBSB {
+		return TableSBGlobal
+	}
+	return TableNBGlobal
+}
+
+// globalRow returns the uuid and the row of the global table
+func (odbi *ovndb) globalRow() (string, libovsdb.Row, error) {
+	table := odbi.globalTable()
+	if err := odbi.requireColumns(table, "connections", "ssl"); err != nil {
+		return "", libovsdb.Row{}, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheGlobal, ok := odbi.cache[table]
+	if !ok {
+		return "", libovsdb.Row{}, ErrorSchema
+	}
+	for uuid, drows := range cacheGlobal {
+		return uuid, drows, nil
+	}
+	return "", libovsdb.Row{}, errorf(ErrorNotFound, "no row in %s table", table)
+}
+
+// globalUpdate updates column of the global row with value after operations,
+// which insert the rows it references
+func (odbi *ovndb) globalUpdate(column string, value interface{}, operations []libovsdb.Operation) (*OvnCommand, error) {
+	uuid, _, err := odbi.globalRow()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row[column] = value
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: odbi.globalTable(),
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations = append(operations, updateOp)
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) newConnectionRow(conn *Connection) (OVNRow, error) {
+	if len(conn.Target) == 0 {
+		return nil, errorf(ErrorOption, "connection target cannot be empty")
+	}
+	row := make(OVNRow)
+	row["target"] = conn.Target
+	if conn.InactivityProbe != nil {
+		if *conn.InactivityProbe < 0 {
+			return nil, errorf(ErrorOption, "inactivity probe %d of %s is negative", *conn.InactivityProbe, conn.Target)
+		}
+		row["inactivity_probe"] = *conn.InactivityProbe
+	}
+	if conn.MaxBackoff != nil {
+		if *conn.MaxBackoff < 1000 {
+			return nil, errorf(ErrorOption, "max backoff %d of %s is below 1000", *conn.MaxBackoff, conn.Target)
+		}
+		row["max_backoff"] = *conn.MaxBackoff
+	}
+	if len(conn.Role) > 0 || conn.ReadOnly {
+		// only the southbound database has role based access control
+		schema, _ := odbi.getSchema()
+		if _, ok := schema.Tables[TableConnection].Columns["role"]; !ok {
+			return nil, errorf(ErrorOption, "database %s has no connection role", odbi.db)
+		}
+		row["role"] = conn.Role
+		row["read_only"] = conn.ReadOnly
+	}
+	if conn.OtherConfig != nil {
+		oMap, err := libovsdb.NewOvsMap(conn.OtherConfig)
+		if err != nil {
+			return nil, err
+		}
+		row["other_config"] = oMap
+	}
+	if conn.ExternalID != nil {
+		oMap, err := libovsdb.NewOvsMap(conn.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	return row, nil
+}
+
+// connectionSetImp replaces the connections of the database, like ovn-nbctl set-connection
+func (odbi *ovndb) connectionSetImp(conns ...*Connection) (*OvnCommand, error) {
+	var operations []libovsdb.Operation
+	uuids := make([]libovsdb.UUID, 0, len(conns))
+	targets := make(map[string]bool)
+	for _, conn := range conns {
+		if targets[conn.Target] {
+			return nil, errorf(ErrorOption, "duplicate connection target %s", conn.Target)
+		}
+		targets[conn.Target] = true
+		row, err := odbi.newConnectionRow(conn)
+		if err != nil {
+			return nil, err
+		}
+		namedUUID, err := newRowUUID()
+		if err != nil {
+			return nil, err
+		}
+		insertOp := libovsdb.Operation{
+			Op:       opInsert,
+			Table:    TableConnection,
+			Row:      row,
+			UUIDName: namedUUID,
+		}
+		operations = append(operations, insertOp)
+		uuids = append(uuids, stringToGoUUID(namedUUID))
+	}
+	// the previous connections are garbage collected
+	connections := libovsdb.OvsSet{GoSet: []interface{}{}}
+	for _, uuid := range uuids {
+		connections.GoSet = append(connections.GoSet, uuid)
+	}
+	return odbi.globalUpdate("connections", connections, operations)
+}
+
+func (odbi *ovndb) connectionDelImp() (*OvnCommand, error) {
+	return odbi.connectionSetImp()
+}
+
+// connectionListImp returns the connections of the database sorted by target
+func (odbi *ovndb) connectionListImp() ([]*Connection, error) {
+	_, global, err := odbi.globalRow()
+	if err != nil {
+		return nil, err
+	}
+	var uuids []string
+	switch connections := global.Fields["connections"].(type) {
+	case libovsdb.UUID:
+		uuids = []string{connections.GoUUID}
+	case libovsdb.OvsSet:
+		uuids = odbi.ConvertGoSetToStringArray(connections)
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	listConn := make([]*Connection, 0, len(uuids))
+	for _, uuid := range uuids {
+		if conn := odbi.rowToConnection(uuid); conn != nil {
+			listConn = append(listConn, conn)
+		}
+	}
+	sort.Slice(listConn, func(i, j int) bool {
+		return listConn[i].Target < listConn[j].Target
+	})
+	return listConn, nil
+}
+
+// sslSetImp replaces the SSL configuration of the database, like ovn-nbctl set-ssl
+func (odbi *ovndb) sslSetImp(ssl *SSLConfig) (*OvnCommand, error) {
+	if len(ssl.PrivateKey) == 0 || len(ssl.Certificate) == 0 || len(ssl.CACert) == 0 {
+		return nil, errorf(ErrorOption, "private key, certificate and CA certificate are required")
+	}
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["private_key"] = ssl.PrivateKey
+	row["certificate"] = ssl.Certificate
+	row["ca_cert"] = ssl.CACert
+	row["bootstrap_ca_cert"] = ssl.BootstrapCACert
+	if len(ssl.SSLProtocols) > 0 {
+		row["ssl_protocols"] = ssl.SSLProtocols
+	}
+	if len(ssl.SSLCiphers) > 0 {
+		row["ssl_ciphers"] = ssl.SSLCiphers
+	}
+	if ssl.ExternalID != nil {
+		oMap, err := libovsdb.NewOvsMap(ssl.ExternalID)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableSSL,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	// the previous SSL row is garbage collected
+	return odbi.globalUpdate("ssl", stringToGoUUID(namedUUID), []libovsdb.Operation{insertOp})
+}
+
+func (odbi *ovndb) sslDelImp() (*OvnCommand, error) {
+	return odbi.globalUpdate("ssl", libovsdb.OvsSet{GoSet: []interface{}{}}, nil)
+}
+
+func (odbi *ovndb) sslGetImp() (*SSLConfig, error) {
+	_, global, err := odbi.globalRow()
+	if err != nil {
+		return nil, err
+	}
+
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	if uuid, ok := global.Fields["ssl"].(libovsdb.UUID); ok {
+		if ssl := odbi.rowToSSL(uuid.GoUUID); ssl != nil {
+			return ssl, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (odbi *ovndb) rowToConnection(uuid string) *Connection {
+	cacheConnection, ok := odbi.cache[TableConnection][uuid]
+	if !ok {
+		return nil
+	}
+
+	target, _ := cacheConnection.Fields["target"].(string)
+	role, _ := cacheConnection.Fields["role"].(string)
+	readOnly, _ := cacheConnection.Fields["read_only"].(bool)
+	isConnected, _ := cacheConnection.Fields["is_connected"].(bool)
+	otherConfig, _ := cacheConnection.Fields["other_config"].(libovsdb.OvsMap)
+	extIDs, _ := cacheConnection.Fields["external_ids"].(libovsdb.OvsMap)
+	status, _ := cacheConnection.Fields["status"].(libovsdb.OvsMap)
+
+	conn := &Connection{
+		UUID:        uuid,
+		Target:      target,
+		Role:        role,
+		ReadOnly:    readOnly,
+		OtherConfig: otherConfig.GoMap,
+		ExternalID:  extIDs.GoMap,
+		IsConnected: isConnected,
+		Status:      status.GoMap,
+	}
+	// optional integers are empty sets when unset
+	if probe, ok := cacheConnection.Fields["inactivity_probe"].(int); ok {
+		conn.InactivityProbe = &probe
+	}
+	if backoff, ok := cacheConnection.Fields["max_backoff"].(int); ok {
+		conn.MaxBackoff = &backoff
+	}
+	return conn
+}
+
+func (odbi *ovndb) rowToSSL(uuid string) *SSLConfig {
+	cacheSSL, ok := odbi.cache[TableSSL][uuid]
+	if !ok {
+		return nil
+	}
+
+	privateKey, _ := cacheSSL.Fields["private_key"].(string)
+	certificate, _ := cacheSSL.Fields["certificate"].(string)
+	caCert, _ := cacheSSL.Fields["ca_cert"].(string)
+	bootstrapCACert, _ := cacheSSL.Fields["bootstrap_ca_cert"].(bool)
+	sslProtocols, _ := cacheSSL.Fields["ssl_protocols"].(string)
+	sslCiphers, _ := cacheSSL.Fields["ssl_ciphers"].(string)
+	extIDs, _ := cacheSSL.Fields["external_ids"].(libovsdb.OvsMap)
+
+	return &SSLConfig{
+		UUID:            uuid,
+		PrivateKey:      privateKey,
+		Certificate:     certificate,
+		CACert:          caCert,
+		BootstrapCACert: bootstrapCACert,
+		SSLProtocols:    sslProtocols,
+		SSLCiphers:      sslCiphers,
+		ExternalID:      extIDs.GoMap,
+	}
+}
@@ -0,0 +1,171 @@
+package goovn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// withGlobalRow runs f with the NB_Global or SB_Global row of the db of ovndbapi
+func withGlobalRow(t *testing.T, ovndbapi Client, f func()) {
+	ovn, ok := ovndbapi.(*ovndb)
+	if !ok {
+		t.Fatal(fmt.Errorf("Invalid type assertion"))
+	}
+	add, del := ovn.nbGlobalAdd, ovn.nbGlobalDel
+	if ovn.db == DBSB {
+		add, del = ovn.sbGlobalAdd, ovn.sbGlobalDel
+	}
+	cmd, err := add(map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := del()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+	f()
+}
+
+func TestConnection(t *testing.T) {
+	for _, db := range []string{DBNB, DBSB} {
+		ovndbapi := getOVNClient(db)
+		withGlobalRow(t, ovndbapi, func() {
+			probe, backoff := 0, 8000
+			conns := []*Connection{
+				{Target: "ptcp:6641"},
+				{Target: "pssl:6642", InactivityProbe: &probe, MaxBackoff: &backoff,
+					ExternalID: map[interface{}]interface{}{"owner": "bootstrap"}},
+			}
+			cmd, err := ovndbapi.ConnectionSet(conns...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ovndbapi.Execute(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			listConn, err := ovndbapi.ConnectionList()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if assert.Equal(t, 2, len(listConn)) {
+				assert.Equal(t, "pssl:6642", listConn[0].Target)
+				if assert.NotNil(t, listConn[0].InactivityProbe) {
+					assert.Equal(t, 0, *listConn[0].InactivityProbe)
+				}
+				if assert.NotNil(t, listConn[0].MaxBackoff) {
+					assert.Equal(t, 8000, *listConn[0].MaxBackoff)
+				}
+				assert.Equal(t, "bootstrap", listConn[0].ExternalID["owner"])
+				assert.Equal(t, "ptcp:6641", listConn[1].Target)
+				assert.Nil(t, listConn[1].InactivityProbe)
+				assert.Nil(t, listConn[1].MaxBackoff)
+			}
+
+			// role based access control is a southbound feature
+			_, err = ovndbapi.ConnectionSet(&Connection{Target: "pssl:6642", Role: "ovn-controller"})
+			if db == DBNB {
+				assert.True(t, isError(err, ErrorOption), err)
+			} else {
+				if err != nil {
+					t.Fatal(err)
+				}
+				cmd, err = ovndbapi.ConnectionSet(&Connection{Target: "pssl:6642", Role: "ovn-controller", ReadOnly: true})
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = ovndbapi.Execute(cmd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				listConn, err = ovndbapi.ConnectionList()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if assert.Equal(t, 1, len(listConn)) {
+					assert.Equal(t, "ovn-controller", listConn[0].Role)
+					assert.True(t, listConn[0].ReadOnly)
+				}
+			}
+			backoff = 10
+			_, err = ovndbapi.ConnectionSet(&Connection{Target: "ptcp:6641", MaxBackoff: &backoff})
+			assert.True(t, isError(err, ErrorOption), err)
+			_, err = ovndbapi.ConnectionSet(&Connection{Target: "ptcp:6641"}, &Connection{Target: "ptcp:6641"})
+			assert.True(t, isError(err, ErrorOption), err)
+
+			cmd, err = ovndbapi.ConnectionDel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ovndbapi.Execute(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			listConn, err = ovndbapi.ConnectionList()
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, 0, len(listConn))
+		})
+		ovndbapi.Close()
+	}
+}
+
+func TestSSL(t *testing.T) {
+	for _, db := range []string{DBNB, DBSB} {
+		ovndbapi := getOVNClient(db)
+		withGlobalRow(t, ovndbapi, func() {
+			_, err := ovndbapi.SSLGet()
+			assert.True(t, isError(err, ErrorNotFound), err)
+			_, err = ovndbapi.SSLSet(&SSLConfig{PrivateKey: "/etc/ovn/key.pem"})
+			assert.True(t, isError(err, ErrorOption), err)
+
+			for _, protocols := range []string{"TLSv1.2", "TLSv1.2,TLSv1.3"} {
+				cmd, err := ovndbapi.SSLSet(&SSLConfig{
+					PrivateKey:   "/etc/ovn/key.pem",
+					Certificate:  "/etc/ovn/cert.pem",
+					CACert:       "/etc/ovn/cacert.pem",
+					SSLProtocols: protocols,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+				err = ovndbapi.Execute(cmd)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ssl, err := ovndbapi.SSLGet()
+				if err != nil {
+					t.Fatal(err)
+				}
+				assert.Equal(t, "/etc/ovn/cert.pem", ssl.Certificate)
+				assert.Equal(t, protocols, ssl.SSLProtocols)
+				assert.False(t, ssl.BootstrapCACert)
+			}
+
+			cmd, err := ovndbapi.SSLDel()
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ovndbapi.Execute(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = ovndbapi.SSLGet()
+			assert.True(t, isError(err, ErrorNotFound), err)
+		})
+		ovndbapi.Close()
+	}
+}
@@ -0,0 +1,102 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"github.com/ebay/libovsdb"
+)
+
+// DatapathBinding ovnsb item, LogicalSwitch or LogicalRouter is the uuid of
+// the ovnnb item it is built from and Name its name
+type DatapathBinding struct {
+	UUID          string
+	TunnelKey     int
+	LogicalSwitch string
+	LogicalRouter string
+	Name          string
+	ExternalID    map[interface{}]interface{}
+}
+
+func (odbi *ovndb) datapathBindingListImp() ([]*DatapathBinding, error) {
+	if err := odbi.requireTable(TableDatapathBinding); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheDatapathBinding := odbi.cache[TableDatapathBinding]
+	listDatapathBinding := make([]*DatapathBinding, 0, len(cacheDatapathBinding))
+	for uuid := range cacheDatapathBinding {
+		listDatapathBinding = append(listDatapathBinding, odbi.rowToDatapathBinding(uuid))
+	}
+	return listDatapathBinding, nil
+}
+
+func (odbi *ovndb) datapathBindingGetImp(uuid string) (*DatapathBinding, error) {
+	if err := odbi.requireTable(TableDatapathBinding); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	dp := odbi.rowToDatapathBinding(uuid)
+	if dp == nil {
+		return nil, ErrorNotFound
+	}
+	return dp, nil
+}
+
+// datapathBindingGetByNBImp returns the datapath of the ovnnb logical switch or router nbUUID
+func (odbi *ovndb) datapathBindingGetByNBImp(nbUUID string) (*DatapathBinding, error) {
+	if err := odbi.requireTable(TableDatapathBinding); err != nil {
+		return nil, err
+	}
+	if err := odbi.requireColumns(TableDatapathBinding, "external_ids"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for uuid := range odbi.cache[TableDatapathBinding] {
+		dp := odbi.rowToDatapathBinding(uuid)
+		if dp.LogicalSwitch == nbUUID || dp.LogicalRouter == nbUUID {
+			return dp, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (odbi *ovndb) rowToDatapathBinding(uuid string) *DatapathBinding {
+	cacheDatapathBinding, ok := odbi.cache[TableDatapathBinding][uuid]
+	if !ok {
+		return nil
+	}
+
+	tunnelKey, _ := cacheDatapathBinding.Fields["tunnel_key"].(int)
+	extIDs, _ := cacheDatapathBinding.Fields["external_ids"].(libovsdb.OvsMap)
+
+	dp := &DatapathBinding{
+		UUID:       uuid,
+		TunnelKey:  tunnelKey,
+		ExternalID: extIDs.GoMap,
+	}
+	// set by ovn-northd
+	dp.LogicalSwitch, _ = extIDs.GoMap["logical-switch"].(string)
+	dp.LogicalRouter, _ = extIDs.GoMap["logical-router"].(string)
+	dp.Name, _ = extIDs.GoMap["name"].(string)
+	return dp
+}
@@ -0,0 +1,88 @@
+package goovn
+
+import (
+	"log"
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	DP_LS_UUID = "8a86f6d6-1b1b-4a5b-9d1c-2d6a0f7c3a11"
+	DP_LS_NAME = "fake-ls"
+	DP_KEY     = 20
+)
+
+// getOVNClientOnRequest returns a southbound client that also monitors SBTablesOnRequest
+func getOVNClientOnRequest() Client {
+	cfg := buildOvnDbConfig(DBSB)
+	cfg.TableCols = make(map[string][]string)
+	for _, table := range append(SBTablesOrder, SBTablesOnRequest...) {
+		cfg.TableCols[table] = []string{}
+	}
+	api, err := NewClient(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return api
+}
+
+// addDatapath adds the datapath of the logical switch DP_LS_UUID, returns its uuid
+func addDatapath(t *testing.T, ovndbapi Client) string {
+	extIDs, err := libovsdb.NewOvsMap(map[string]string{"logical-switch": DP_LS_UUID, "name": DP_LS_NAME})
+	if err != nil {
+		t.Fatal(err)
+	}
+	row := make(OVNRow)
+	row["tunnel_key"] = DP_KEY
+	row["external_ids"] = extIDs
+	operations := []libovsdb.Operation{{Op: opInsert, Table: TableDatapathBinding, Row: row, UUIDName: "datapath"}}
+	cmd := &OvnCommand{operations, ovndbapi.(*ovndb), make([][]map[string]interface{}, len(operations))}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uuid, err := cmd.CreatedUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uuid
+}
+
+func TestDatapathBinding(t *testing.T) {
+	ovndbapi := getOVNClientOnRequest()
+	defer ovndbapi.Close()
+
+	uuid := addDatapath(t, ovndbapi)
+	defer sbTransact(t, ovndbapi, libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding,
+		Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", DP_KEY)}})
+
+	dps, err := ovndbapi.DatapathBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(dps))
+	dp, err := ovndbapi.DatapathBindingGet(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, DP_KEY, dp.TunnelKey)
+	assert.Equal(t, DP_LS_UUID, dp.LogicalSwitch)
+	assert.Empty(t, dp.LogicalRouter)
+	assert.Equal(t, DP_LS_NAME, dp.Name)
+
+	dp, err = ovndbapi.DatapathBindingGetByNB(DP_LS_UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, uuid, dp.UUID)
+	_, err = ovndbapi.DatapathBindingGetByNB(FAKENOSWITCH)
+	assert.True(t, isError(err, ErrorNotFound), err)
+
+	// the default client leaves Datapath_Binding out
+	api := getOVNClient(DBSB)
+	defer api.Close()
+	_, err = api.DatapathBindingList()
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+}
@@ -34,11 +34,15 @@ func (odbi *ovndb) rowToDHCPOptions(uuid string) *DHCPOptions {
 		return nil
 	}
 
+	cidr, _ := cacheDHCPOptions.Fields["cidr"].(string)
+	options, _ := cacheDHCPOptions.Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := cacheDHCPOptions.Fields["external_ids"].(libovsdb.OvsMap)
+
 	dhcp := &DHCPOptions{
 		UUID:       uuid,
-		CIDR:       cacheDHCPOptions.Fields["cidr"].(string),
-		Options:    cacheDHCPOptions.Fields["options"].(libovsdb.OvsMap).GoMap,
-		ExternalID: cacheDHCPOptions.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		CIDR:       cidr,
+		Options:    options.GoMap,
+		ExternalID: extIDs.GoMap,
 	}
 
 	return dhcp
@@ -144,6 +148,9 @@ func (odbi *ovndb) dhcpOptionsDelImp(uuid string) (*OvnCommand, error) {
 
 // List all dhcp options
 func (odbi *ovndb) dhcpOptionsListImp() ([]*DHCPOptions, error) {
+	if err := odbi.requireTable(TableDHCPOptions); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -214,3 +214,85 @@ func MapInterfaceToMapString(m map[interface{}]interface{}) map[string]string {
 	}
 	return mapString
 }
+
+func TestDHCPOptionsCreatedUUID(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	lsCmd, err := ovndbapi.LSAdd(LSW2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dhcpCmd, err := ovndbapi.DHCPOptionsAdd("192.168.0.0/24",
+		map[string]string{"server_id": "192.168.1.1", "server_mac": "54:54:54:54:54:54", "lease_time": "6000"},
+		nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = dhcpCmd.CreatedUUID()
+	assert.Equal(t, ErrorNotFound, err)
+	err = ovndbapi.Execute(lsCmd, dhcpCmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dhcpUUID, err := dhcpCmd.CreatedUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.DHCPOptionsDel(dhcpUUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+	defer func() {
+		cmd, err := ovndbapi.LSDel(LSW2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	lsws, err := ovndbapi.LSGet(LSW2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsUUID, err := lsCmd.CreatedUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, lsws[0].UUID, lsUUID)
+	dhcpOpts, err := ovndbapi.DHCPOptionsList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(dhcpOpts)) {
+		assert.Equal(t, dhcpOpts[0].UUID, dhcpUUID)
+	}
+
+	// the created options are set on a port right away
+	lspCmd, err := ovndbapi.LSPAdd(LSW2, LSP2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	setCmd, err := ovndbapi.LSPSetDHCPv4Options(LSP2, dhcpUUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(lspCmd, setCmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := ovndbapi.LSPGetDHCPv4Options(LSP2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, dhcpUUID, options.UUID)
+}
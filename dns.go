@@ -0,0 +1,222 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"github.com/ebay/libovsdb"
+)
+
+// DNS ovnnb item
+type DNS struct {
+	UUID       string
+	Records    map[interface{}]interface{}
+	ExternalID map[interface{}]interface{}
+}
+
+func (odbi *ovndb) rowToDNS(uuid string) *DNS {
+	cacheDNS, ok := odbi.cache[TableDNS][uuid]
+	if !ok {
+		return nil
+	}
+
+	records, _ := cacheDNS.Fields["records"].(libovsdb.OvsMap)
+	extIDs, _ := cacheDNS.Fields["external_ids"].(libovsdb.OvsMap)
+
+	return &DNS{
+		UUID:       uuid,
+		Records:    records.GoMap,
+		ExternalID: extIDs.GoMap,
+	}
+}
+
+func newDNSRow(records map[string]string, external_ids map[string]string) (OVNRow, error) {
+	row := make(OVNRow)
+
+	if records != nil {
+		oMap, err := libovsdb.NewOvsMap(records)
+		if err != nil {
+			return nil, err
+		}
+		row["records"] = oMap
+	}
+
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+
+	return row, nil
+}
+
+func (odbi *ovndb) dnsAddImp(records map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := newDNSRow(records, external_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableDNS,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) dnsSetImp(uuid string, records map[string]string, external_ids map[string]string) (*OvnCommand, error) {
+	if _, err := odbi.dnsGetImp(uuid); err != nil {
+		return nil, err
+	}
+
+	if records == nil {
+		return nil, errorf(ErrorOption, "records cannot be nil")
+	}
+
+	row, err := newDNSRow(records, external_ids)
+	if err != nil {
+		return nil, err
+	}
+
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableDNS,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) dnsDelImp(uuid string) (*OvnCommand, error) {
+	if _, err := odbi.dnsGetImp(uuid); err != nil {
+		return nil, err
+	}
+
+	// dns_records of logical switches are weak references, they drop the row
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableDNS,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) dnsGetImp(uuid string) (*DNS, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	dns := odbi.rowToDNS(uuid)
+	if dns == nil {
+		return nil, ErrorNotFound
+	}
+	return dns, nil
+}
+
+// List all DNS rows
+func (odbi *ovndb) dnsListImp() ([]*DNS, error) {
+	if err := odbi.requireTable(TableDNS); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	cacheDNS, ok := odbi.cache[TableDNS]
+	if !ok {
+		return nil, ErrorSchema
+	}
+
+	listDNS := make([]*DNS, 0, len(cacheDNS))
+	for uuid := range cacheDNS {
+		listDNS = append(listDNS, odbi.rowToDNS(uuid))
+	}
+	return listDNS, nil
+}
+
+// lsDNSMutate inserts or deletes the DNS row uuid in the dns_records of lswitch
+func (odbi *ovndb) lsDNSMutate(lswitch, uuid, op string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = lswitch
+	lsuuid, err := odbi.getRowUUID(TableLogicalSwitch, row)
+	if err != nil {
+		return nil, err
+	}
+	if len(lsuuid) == 0 {
+		return nil, ErrorNotFound
+	}
+	if _, err := odbi.dnsGetImp(uuid); err != nil {
+		return nil, err
+	}
+
+	mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(uuid)})
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("dns_records", op, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", lswitch)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalSwitch,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lsDNSAddImp(lswitch, uuid string) (*OvnCommand, error) {
+	return odbi.lsDNSMutate(lswitch, uuid, opInsert)
+}
+
+func (odbi *ovndb) lsDNSDelImp(lswitch, uuid string) (*OvnCommand, error) {
+	return odbi.lsDNSMutate(lswitch, uuid, opDelete)
+}
+
+func (odbi *ovndb) lsDNSListImp(lswitch string) ([]*DNS, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "dns_records"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for uuid, drows := range odbi.cache[TableLogicalSwitch] {
+		if rlsw, ok := drows.Fields["name"].(string); ok && rlsw == lswitch {
+			ls := odbi.rowToLogicalSwitch(uuid)
+			listDNS := make([]*DNS, 0, len(ls.DNSRecords))
+			for _, dnsUUID := range ls.DNSRecords {
+				if dns := odbi.rowToDNS(dnsUUID); dns != nil {
+					listDNS = append(listDNS, dns)
+				}
+			}
+			return listDNS, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
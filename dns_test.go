@@ -0,0 +1,136 @@
+package goovn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNS(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+
+	cmd, err := ovndbapi.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.LSDel(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	cmd, err = ovndbapi.DNSAdd(map[string]string{"vm1.example.org": "10.0.0.4"}, map[string]string{"tenant": "t1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uuid, err := cmd.CreatedUUID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dns, err := ovndbapi.DNSGet(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "10.0.0.4", dns.Records["vm1.example.org"])
+	assert.Equal(t, "t1", dns.ExternalID["tenant"])
+
+	cmd, err = ovndbapi.DNSSet(uuid, map[string]string{"vm1.example.org": "10.0.0.5", "vm2.example.org": "10.0.0.6"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dns, err = ovndbapi.DNSGet(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(dns.Records))
+	assert.Equal(t, "10.0.0.5", dns.Records["vm1.example.org"])
+	_, err = ovndbapi.DNSSet(uuid, nil, nil)
+	assert.True(t, isError(err, ErrorOption))
+
+	dnsList, err := ovndbapi.DNSList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(dnsList))
+
+	cmd, err = ovndbapi.LSDNSAdd(LSW, uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dnsList, err = ovndbapi.LSDNSList(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(dnsList)) {
+		assert.Equal(t, uuid, dnsList[0].UUID)
+	}
+	_, err = ovndbapi.LSDNSAdd(LSW, "00000000-0000-0000-0000-000000000000")
+	assert.True(t, isError(err, ErrorNotFound))
+	_, err = ovndbapi.LSDNSAdd(LSW2, uuid)
+	assert.True(t, isError(err, ErrorNotFound))
+
+	cmd, err = ovndbapi.LSDNSDel(LSW, uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dnsList, err = ovndbapi.LSDNSList(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(dnsList))
+
+	// deleting an attached DNS drops it from the switch
+	cmd, err = ovndbapi.LSDNSAdd(LSW, uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = ovndbapi.DNSDel(uuid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dnsList, err = ovndbapi.LSDNSList(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(dnsList))
+	dnsList, err = ovndbapi.DNSList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(dnsList))
+	_, err = ovndbapi.DNSDel(uuid)
+	assert.True(t, isError(err, ErrorNotFound))
+}
@@ -31,6 +31,9 @@ type Encap struct {
 }
 
 func (odbi *ovndb) encapListImp(chassisName string) ([]*Encap, error) {
+	if err := odbi.requireColumns(TableChassis, "name", "encaps"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -82,12 +85,16 @@ func (odbi *ovndb) rowToEncap(uuid string) (*Encap, error) {
 	if !ok {
 		return nil, fmt.Errorf("Encap with uuid%s not found", uuid)
 	}
+	chassisName, _ := cacheEncaps.Fields["chassis_name"].(string)
+	ip, _ := cacheEncaps.Fields["ip"].(string)
+	options, _ := cacheEncaps.Fields["options"].(libovsdb.OvsMap)
+	encapType, _ := cacheEncaps.Fields["type"].(string)
 	en := &Encap{
 		UUID:        uuid,
-		ChassisName: cacheEncaps.Fields["chassis_name"].(string),
-		Ip:          cacheEncaps.Fields["ip"].(string),
-		Options:     cacheEncaps.Fields["options"].(libovsdb.OvsMap).GoMap,
-		Encaptype:   cacheEncaps.Fields["type"].(string),
+		ChassisName: chassisName,
+		Ip:          ip,
+		Options:     options.GoMap,
+		Encaptype:   encapType,
 	}
 	return en, nil
 }
@@ -0,0 +1,250 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+// The accessors below return Old and New of an event as the object of its table, e.g.
+//
+//	sub := client.Subscribe(TableLogicalSwitch, func(event Event) {
+//		old, new := event.LogicalSwitch()
+//		...
+//	})
+//
+// Both are nil for the events of other tables.
+
+// LogicalSwitch returns Old and New of an event of TableLogicalSwitch
+func (e Event) LogicalSwitch() (old, new *LogicalSwitch) {
+	old, _ = e.Old.(*LogicalSwitch)
+	new, _ = e.New.(*LogicalSwitch)
+	return old, new
+}
+
+// LogicalSwitchPort returns Old and New of an event of TableLogicalSwitchPort
+func (e Event) LogicalSwitchPort() (old, new *LogicalSwitchPort) {
+	old, _ = e.Old.(*LogicalSwitchPort)
+	new, _ = e.New.(*LogicalSwitchPort)
+	return old, new
+}
+
+// LogicalRouter returns Old and New of an event of TableLogicalRouter
+func (e Event) LogicalRouter() (old, new *LogicalRouter) {
+	old, _ = e.Old.(*LogicalRouter)
+	new, _ = e.New.(*LogicalRouter)
+	return old, new
+}
+
+// LogicalRouterPort returns Old and New of an event of TableLogicalRouterPort
+func (e Event) LogicalRouterPort() (old, new *LogicalRouterPort) {
+	old, _ = e.Old.(*LogicalRouterPort)
+	new, _ = e.New.(*LogicalRouterPort)
+	return old, new
+}
+
+// LogicalRouterStaticRoute returns Old and New of an event of TableLogicalRouterStaticRoute
+func (e Event) LogicalRouterStaticRoute() (old, new *LogicalRouterStaticRoute) {
+	old, _ = e.Old.(*LogicalRouterStaticRoute)
+	new, _ = e.New.(*LogicalRouterStaticRoute)
+	return old, new
+}
+
+// LogicalRouterPolicy returns Old and New of an event of TableLogicalRouterPolicy
+func (e Event) LogicalRouterPolicy() (old, new *LogicalRouterPolicy) {
+	old, _ = e.Old.(*LogicalRouterPolicy)
+	new, _ = e.New.(*LogicalRouterPolicy)
+	return old, new
+}
+
+// ACL returns Old and New of an event of TableACL
+func (e Event) ACL() (old, new *ACL) {
+	old, _ = e.Old.(*ACL)
+	new, _ = e.New.(*ACL)
+	return old, new
+}
+
+// AddressSet returns Old and New of an event of TableAddressSet
+func (e Event) AddressSet() (old, new *AddressSet) {
+	old, _ = e.Old.(*AddressSet)
+	new, _ = e.New.(*AddressSet)
+	return old, new
+}
+
+// PortGroup returns Old and New of an event of TablePortGroup
+func (e Event) PortGroup() (old, new *PortGroup) {
+	old, _ = e.Old.(*PortGroup)
+	new, _ = e.New.(*PortGroup)
+	return old, new
+}
+
+// DHCPOptions returns Old and New of an event of TableDHCPOptions
+func (e Event) DHCPOptions() (old, new *DHCPOptions) {
+	old, _ = e.Old.(*DHCPOptions)
+	new, _ = e.New.(*DHCPOptions)
+	return old, new
+}
+
+// DNS returns Old and New of an event of TableDNS
+func (e Event) DNS() (old, new *DNS) {
+	old, _ = e.Old.(*DNS)
+	new, _ = e.New.(*DNS)
+	return old, new
+}
+
+// QoS returns Old and New of an event of TableQoS
+func (e Event) QoS() (old, new *QoS) {
+	old, _ = e.Old.(*QoS)
+	new, _ = e.New.(*QoS)
+	return old, new
+}
+
+// LoadBalancer returns Old and New of an event of TableLoadBalancer
+func (e Event) LoadBalancer() (old, new *LoadBalancer) {
+	old, _ = e.Old.(*LoadBalancer)
+	new, _ = e.New.(*LoadBalancer)
+	return old, new
+}
+
+// Meter returns Old and New of an event of TableMeter
+func (e Event) Meter() (old, new *Meter) {
+	old, _ = e.Old.(*Meter)
+	new, _ = e.New.(*Meter)
+	return old, new
+}
+
+// MeterBand returns Old and New of an event of TableMeterBand
+func (e Event) MeterBand() (old, new *MeterBand) {
+	old, _ = e.Old.(*MeterBand)
+	new, _ = e.New.(*MeterBand)
+	return old, new
+}
+
+// NAT returns Old and New of an event of TableNAT
+func (e Event) NAT() (old, new *NAT) {
+	old, _ = e.Old.(*NAT)
+	new, _ = e.New.(*NAT)
+	return old, new
+}
+
+// GatewayChassis returns Old and New of an event of TableGatewayChassis
+func (e Event) GatewayChassis() (old, new *GatewayChassis) {
+	old, _ = e.Old.(*GatewayChassis)
+	new, _ = e.New.(*GatewayChassis)
+	return old, new
+}
+
+// HAChassis returns Old and New of an event of TableHAChassis
+func (e Event) HAChassis() (old, new *HAChassis) {
+	old, _ = e.Old.(*HAChassis)
+	new, _ = e.New.(*HAChassis)
+	return old, new
+}
+
+// HAChassisGroup returns Old and New of an event of TableHAChassisGroup
+func (e Event) HAChassisGroup() (old, new *HAChassisGroup) {
+	old, _ = e.Old.(*HAChassisGroup)
+	new, _ = e.New.(*HAChassisGroup)
+	return old, new
+}
+
+// NBGlobal returns Old and New of an event of TableNBGlobal
+func (e Event) NBGlobal() (old, new *NBGlobalTableRow) {
+	old, _ = e.Old.(*NBGlobalTableRow)
+	new, _ = e.New.(*NBGlobalTableRow)
+	return old, new
+}
+
+// SBGlobal returns Old and New of an event of TableSBGlobal
+func (e Event) SBGlobal() (old, new *SBGlobalTableRow) {
+	old, _ = e.Old.(*SBGlobalTableRow)
+	new, _ = e.New.(*SBGlobalTableRow)
+	return old, new
+}
+
+// Connection returns Old and New of an event of TableConnection
+func (e Event) Connection() (old, new *Connection) {
+	old, _ = e.Old.(*Connection)
+	new, _ = e.New.(*Connection)
+	return old, new
+}
+
+// SSL returns Old and New of an event of TableSSL
+func (e Event) SSL() (old, new *SSLConfig) {
+	old, _ = e.Old.(*SSLConfig)
+	new, _ = e.New.(*SSLConfig)
+	return old, new
+}
+
+// Chassis returns Old and New of an event of TableChassis
+func (e Event) Chassis() (old, new *Chassis) {
+	old, _ = e.Old.(*Chassis)
+	new, _ = e.New.(*Chassis)
+	return old, new
+}
+
+// ChassisPrivate returns Old and New of an event of TableChassisPrivate
+func (e Event) ChassisPrivate() (old, new *ChassisPrivate) {
+	old, _ = e.Old.(*ChassisPrivate)
+	new, _ = e.New.(*ChassisPrivate)
+	return old, new
+}
+
+// Encap returns Old and New of an event of TableEncap
+func (e Event) Encap() (old, new *Encap) {
+	old, _ = e.Old.(*Encap)
+	new, _ = e.New.(*Encap)
+	return old, new
+}
+
+// PortBinding returns Old and New of an event of TablePortBinding
+func (e Event) PortBinding() (old, new *PortBinding) {
+	old, _ = e.Old.(*PortBinding)
+	new, _ = e.New.(*PortBinding)
+	return old, new
+}
+
+// DatapathBinding returns Old and New of an event of TableDatapathBinding
+func (e Event) DatapathBinding() (old, new *DatapathBinding) {
+	old, _ = e.Old.(*DatapathBinding)
+	new, _ = e.New.(*DatapathBinding)
+	return old, new
+}
+
+// LogicalFlow returns Old and New of an event of TableLogicalFlow
+func (e Event) LogicalFlow() (old, new *LogicalFlow) {
+	old, _ = e.Old.(*LogicalFlow)
+	new, _ = e.New.(*LogicalFlow)
+	return old, new
+}
+
+// LogicalDPGroup returns Old and New of an event of TableLogicalDPGroup
+func (e Event) LogicalDPGroup() (old, new *LogicalDPGroup) {
+	old, _ = e.Old.(*LogicalDPGroup)
+	new, _ = e.New.(*LogicalDPGroup)
+	return old, new
+}
+
+// MACBinding returns Old and New of an event of TableMACBinding
+func (e Event) MACBinding() (old, new *MACBinding) {
+	old, _ = e.Old.(*MACBinding)
+	new, _ = e.New.(*MACBinding)
+	return old, new
+}
+
+// FDB returns Old and New of an event of TableFDB
+func (e Event) FDB() (old, new *FDB) {
+	old, _ = e.Old.(*FDB)
+	new, _ = e.New.(*FDB)
+	return old, new
+}
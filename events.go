@@ -0,0 +1,401 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"sync"
+	"time"
+)
+
+// EventType tells how a row changed
+type EventType int
+
+const (
+	EventCreate EventType = iota
+	EventUpdate
+	EventDelete
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventCreate:
+		return "create"
+	case EventUpdate:
+		return "update"
+	case EventDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Event is the change of a row of a monitored table. Old and New hold the row before and
+// after it, nil when it did not exist, as the object of the table, e.g. *LogicalSwitchPort
+// for TableLogicalSwitchPort, which typed accessors like Event.LogicalSwitchPort return.
+type Event struct {
+	Type  EventType
+	Table string
+	UUID  string
+	Old   interface{}
+	New   interface{}
+}
+
+// EventHandler is called with the events of a subscription
+type EventHandler func(event Event)
+
+// EventPredicate selects the events of a subscription
+type EventPredicate func(event Event) bool
+
+// OverflowPolicy tells what becomes of the events of a subscription whose queue is full
+type OverflowPolicy int
+
+const (
+	// Drop the event, the policy of a bounded queue unless set otherwise
+	OverflowDropNewest OverflowPolicy = iota
+	// Drop the oldest queued event to make room for the event
+	OverflowDropOldest
+	// Wait for the handler to take an event, which holds up the monitor updates and the
+	// replies of the client meanwhile, so the handler must not wait for the client then
+	OverflowBlock
+)
+
+// QueueConfig configures the queue a subscription handles its events from, in its own goroutine
+type QueueConfig struct {
+	Size     int            // Number of events queued, the queue grows as needed if 0
+	Overflow OverflowPolicy // What to do with events once Size are queued, OverflowDropNewest by default
+}
+
+// SubscriptionStats are the counters of the events of a subscription
+type SubscriptionStats struct {
+	Handled    uint64        // Events the handler was called with
+	Dropped    uint64        // Events dropped by the overflow policy
+	Delayed    uint64        // Events that waited for room in the queue
+	Queued     int           // Events in the queue
+	MaxLatency time.Duration // Longest time from the cache update to the handler call
+}
+
+// Subscription is returned by Client.Subscribe
+type Subscription struct {
+	odbi       *ovndb
+	table      string
+	handler    EventHandler
+	predicates []EventPredicate
+
+	queue    []queuedEvent
+	size     int // 0 if the queue is not bounded
+	overflow OverflowPolicy
+	mutex    sync.Mutex
+	// signaled when events are queued or taken and when the subscription is closed
+	cond   *sync.Cond
+	closed bool
+	stats  SubscriptionStats
+}
+
+type queuedEvent struct {
+	event Event
+	time  time.Time
+}
+
+// Unsubscribe stops the events of the subscription, queued events are dropped.
+// The handler may still be running with the current one.
+func (s *Subscription) Unsubscribe() {
+	s.odbi.submutex.Lock()
+	for i, sub := range s.odbi.subscriptions {
+		if sub == s {
+			// the slice may be in use by publish
+			subs := make([]*Subscription, 0, len(s.odbi.subscriptions)-1)
+			subs = append(subs, s.odbi.subscriptions[:i]...)
+			s.odbi.subscriptions = append(subs, s.odbi.subscriptions[i+1:]...)
+			break
+		}
+	}
+	s.odbi.submutex.Unlock()
+	s.close()
+}
+
+// Stats returns the counters of the events of the subscription
+func (s *Subscription) Stats() SubscriptionStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	stats := s.stats
+	stats.Queued = len(s.queue)
+	return stats
+}
+
+func (s *Subscription) matches(event Event) bool {
+	if s.table != "" && s.table != event.Table {
+		return false
+	}
+	for _, predicate := range s.predicates {
+		if !predicate(event) {
+			return false
+		}
+	}
+	return true
+}
+
+// deliver handles event, or queues it according to the overflow policy
+func (s *Subscription) deliver(event Event, published time.Time) {
+	s.mutex.Lock()
+	if s.closed {
+		s.mutex.Unlock()
+		return
+	}
+	defer s.mutex.Unlock()
+	if s.size > 0 && len(s.queue) >= s.size {
+		switch s.overflow {
+		case OverflowDropNewest:
+			s.stats.Dropped++
+			return
+		case OverflowDropOldest:
+			s.queue = s.queue[1:]
+			s.stats.Dropped++
+		default:
+			s.stats.Delayed++
+			for len(s.queue) >= s.size && !s.closed {
+				s.cond.Wait()
+			}
+			if s.closed {
+				return
+			}
+		}
+	}
+	s.queue = append(s.queue, queuedEvent{event, published})
+	s.cond.Broadcast()
+}
+
+func (s *Subscription) handle(e queuedEvent) {
+	latency := time.Since(e.time)
+	s.handler(e.event)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.stats.Handled++
+	if latency > s.stats.MaxLatency {
+		s.stats.MaxLatency = latency
+	}
+}
+
+// run handles the queued events until the subscription is closed
+func (s *Subscription) run() {
+	s.mutex.Lock()
+	for {
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if s.closed {
+			s.mutex.Unlock()
+			return
+		}
+		e := s.queue[0]
+		s.queue = s.queue[1:]
+		s.cond.Broadcast()
+		s.mutex.Unlock()
+		s.handle(e)
+		s.mutex.Lock()
+	}
+}
+
+func (s *Subscription) close() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.closed = true
+	s.queue = nil
+	s.cond.Broadcast()
+}
+
+func (odbi *ovndb) subscribeImp(table string, handler EventHandler, queue QueueConfig, predicates []EventPredicate) *Subscription {
+	if odbi.txn != nil {
+		// the events are those of the client of the transaction
+		return odbi.txn.parent.subscribeImp(table, handler, queue, predicates)
+	}
+	// the handlers do not run in the goroutine reading the monitor updates, as they may
+	// wait for the client
+	sub := &Subscription{odbi: odbi, table: table, handler: handler, predicates: predicates}
+	if queue.Size > 0 {
+		sub.size = queue.Size
+		sub.overflow = queue.Overflow
+	}
+	sub.cond = sync.NewCond(&sub.mutex)
+	go sub.run()
+	odbi.submutex.Lock()
+	defer odbi.submutex.Unlock()
+	odbi.subscriptions = append(odbi.subscriptions, sub)
+	return sub
+}
+
+// publish delivers events to the subscriptions in the order they were made
+func (odbi *ovndb) publish(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+	now := time.Now()
+	odbi.submutex.Lock()
+	subs := odbi.subscriptions
+	odbi.submutex.Unlock()
+	for _, event := range events {
+		for _, sub := range subs {
+			if sub.matches(event) {
+				sub.deliver(event, now)
+			}
+		}
+	}
+}
+
+// unsubscribeAll closes all subscriptions, stopping the goroutines of their queues
+func (odbi *ovndb) unsubscribeAll() {
+	odbi.submutex.Lock()
+	subs := odbi.subscriptions
+	odbi.subscriptions = nil
+	odbi.submutex.Unlock()
+	for _, sub := range subs {
+		sub.close()
+	}
+}
+
+// SignalHandler adapts signal to the events of all tables. Modified rows are signaled as
+// created, unless signal implements OVNUpdateSignal.
+func SignalHandler(signal OVNSignal) EventHandler {
+	updateCB, _ := signal.(OVNUpdateSignal)
+	return func(event Event) {
+		switch {
+		case event.Type == EventUpdate && updateCB != nil:
+			signalUpdate(updateCB, event)
+		case event.Type == EventDelete:
+			signalDelete(signal, event)
+		default:
+			signalCreate(signal, event)
+		}
+	}
+}
+
+func signalCreate(signal OVNSignal, event Event) {
+	switch obj := event.New.(type) {
+	case *LogicalRouter:
+		signal.OnLogicalRouterCreate(obj)
+	case *LogicalRouterPort:
+		signal.OnLogicalRouterPortCreate(obj)
+	case *LogicalRouterStaticRoute:
+		signal.OnLogicalRouterStaticRouteCreate(obj)
+	case *LogicalSwitch:
+		signal.OnLogicalSwitchCreate(obj)
+	case *LogicalSwitchPort:
+		signal.OnLogicalPortCreate(obj)
+	case *ACL:
+		signal.OnACLCreate(obj)
+	case *DHCPOptions:
+		signal.OnDHCPOptionsCreate(obj)
+	case *QoS:
+		signal.OnQoSCreate(obj)
+	case *LoadBalancer:
+		signal.OnLoadBalancerCreate(obj)
+	case *Meter:
+		signal.OnMeterCreate(obj)
+	case *MeterBand:
+		signal.OnMeterBandCreate(obj)
+	case *Chassis:
+		signal.OnChassisCreate(obj)
+	case *Encap:
+		signal.OnEncapCreate(obj)
+	}
+}
+
+func signalDelete(signal OVNSignal, event Event) {
+	switch obj := event.Old.(type) {
+	case *LogicalRouter:
+		signal.OnLogicalRouterDelete(obj)
+	case *LogicalRouterPort:
+		signal.OnLogicalRouterPortDelete(obj)
+	case *LogicalRouterStaticRoute:
+		signal.OnLogicalRouterStaticRouteDelete(obj)
+	case *LogicalSwitch:
+		signal.OnLogicalSwitchDelete(obj)
+	case *LogicalSwitchPort:
+		signal.OnLogicalPortDelete(obj)
+	case *ACL:
+		signal.OnACLDelete(obj)
+	case *DHCPOptions:
+		signal.OnDHCPOptionsDelete(obj)
+	case *QoS:
+		signal.OnQoSDelete(obj)
+	case *LoadBalancer:
+		signal.OnLoadBalancerDelete(obj)
+	case *Meter:
+		signal.OnMeterDelete(obj)
+	case *MeterBand:
+		signal.OnMeterBandDelete(obj)
+	case *Chassis:
+		signal.OnChassisDelete(obj)
+	case *Encap:
+		signal.OnEncapDelete(obj)
+	}
+}
+
+func signalUpdate(updateCB OVNUpdateSignal, event Event) {
+	switch old := event.Old.(type) {
+	case *LogicalRouter:
+		if obj, ok := event.New.(*LogicalRouter); ok {
+			updateCB.OnLogicalRouterUpdate(old, obj)
+		}
+	case *LogicalRouterPort:
+		if obj, ok := event.New.(*LogicalRouterPort); ok {
+			updateCB.OnLogicalRouterPortUpdate(old, obj)
+		}
+	case *LogicalRouterStaticRoute:
+		if obj, ok := event.New.(*LogicalRouterStaticRoute); ok {
+			updateCB.OnLogicalRouterStaticRouteUpdate(old, obj)
+		}
+	case *LogicalSwitch:
+		if obj, ok := event.New.(*LogicalSwitch); ok {
+			updateCB.OnLogicalSwitchUpdate(old, obj)
+		}
+	case *LogicalSwitchPort:
+		if obj, ok := event.New.(*LogicalSwitchPort); ok {
+			updateCB.OnLogicalPortUpdate(old, obj)
+		}
+	case *ACL:
+		if obj, ok := event.New.(*ACL); ok {
+			updateCB.OnACLUpdate(old, obj)
+		}
+	case *DHCPOptions:
+		if obj, ok := event.New.(*DHCPOptions); ok {
+			updateCB.OnDHCPOptionsUpdate(old, obj)
+		}
+	case *QoS:
+		if obj, ok := event.New.(*QoS); ok {
+			updateCB.OnQoSUpdate(old, obj)
+		}
+	case *LoadBalancer:
+		if obj, ok := event.New.(*LoadBalancer); ok {
+			updateCB.OnLoadBalancerUpdate(old, obj)
+		}
+	case *Meter:
+		if obj, ok := event.New.(*Meter); ok {
+			updateCB.OnMeterUpdate(old, obj)
+		}
+	case *MeterBand:
+		if obj, ok := event.New.(*MeterBand); ok {
+			updateCB.OnMeterBandUpdate(old, obj)
+		}
+	case *Chassis:
+		if obj, ok := event.New.(*Chassis); ok {
+			updateCB.OnChassisUpdate(old, obj)
+		}
+	case *Encap:
+		if obj, ok := event.New.(*Encap); ok {
+			updateCB.OnEncapUpdate(old, obj)
+		}
+	}
+}
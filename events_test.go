@@ -0,0 +1,329 @@
+package goovn
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// eventRecorder records the events it handles
+type eventRecorder struct {
+	mutex  sync.Mutex
+	events []Event
+}
+
+func (r *eventRecorder) handle(event Event) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) recorded() []Event {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.events
+}
+
+// wait returns the recorded events once there are n of them
+func (r *eventRecorder) wait(t *testing.T, n int) []Event {
+	assert.Eventually(t, func() bool {
+		return len(r.recorded()) >= n
+	}, time.Second, 10*time.Millisecond)
+	return r.recorded()
+}
+
+func TestSubscribe(t *testing.T) {
+	api := getOVNClient(DBNB)
+	defer api.Close()
+
+	ports, created, all := &eventRecorder{}, &eventRecorder{}, &eventRecorder{}
+	sub := api.Subscribe(TableLogicalSwitchPort, ports.handle)
+	defer sub.Unsubscribe()
+	sub = api.Subscribe(TableLogicalSwitchPort, created.handle, func(event Event) bool {
+		return event.Type == EventCreate
+	})
+	defer sub.Unsubscribe()
+	allSub := api.Subscribe("", all.handle)
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = api.LSPAdd(LSW, LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = api.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded := ports.wait(t, 1); assert.Equal(t, 1, len(recorded)) {
+		event := recorded[0]
+		assert.Equal(t, EventCreate, event.Type)
+		assert.Nil(t, event.Old)
+		assert.Equal(t, LSP, event.New.(*LogicalSwitchPort).Name)
+	}
+	assert.Equal(t, 2, len(all.wait(t, 2)))
+
+	allSub.Unsubscribe()
+	cmd, err = api.LSPSetAddress(LSP, ADDR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded := ports.wait(t, 2); assert.Equal(t, 2, len(recorded)) {
+		event := recorded[1]
+		assert.Equal(t, EventUpdate, event.Type)
+		old, new := event.LogicalSwitchPort()
+		assert.Equal(t, 0, len(old.Addresses))
+		assert.Equal(t, []string{ADDR}, new.Addresses)
+		oldLS, newLS := event.LogicalSwitch()
+		assert.Nil(t, oldLS)
+		assert.Nil(t, newLS)
+	}
+
+	cmd, err = api.LSDel(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded := ports.wait(t, 3); assert.Equal(t, 3, len(recorded)) {
+		event := recorded[2]
+		assert.Equal(t, EventDelete, event.Type)
+		assert.Equal(t, LSP, event.Old.(*LogicalSwitchPort).Name)
+		assert.Nil(t, event.New)
+	}
+	assert.Equal(t, 1, len(created.recorded()))
+	assert.Equal(t, 2, len(all.recorded()))
+}
+
+func TestSubscribeExecute(t *testing.T) {
+	api := getOVNClient(DBNB)
+	defer api.Close()
+
+	// handlers run apart from the monitor updates, they may wait for the client
+	added := make(chan error, 1)
+	sub := api.Subscribe(TableLogicalSwitch, func(event Event) {
+		_, ls := event.LogicalSwitch()
+		if event.Type != EventCreate || ls.Name != LSW {
+			return
+		}
+		cmd, err := api.LSPAdd(LSW, LSP)
+		if err == nil {
+			err = api.Execute(cmd)
+		}
+		added <- err
+	})
+	defer sub.Unsubscribe()
+
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = api.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := api.LSDel(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = api.Execute(cmd); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	select {
+	case err = <-added:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler did not add the port")
+	}
+	lsp, err := api.LSPGet(LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, LSP, lsp.Name)
+}
+
+func TestEventObjects(t *testing.T) {
+	api := getOVNClient(DBNB)
+	defer api.Close()
+
+	sets := &eventRecorder{}
+	sub := api.Subscribe(TableAddressSet, sets.handle)
+	defer sub.Unsubscribe()
+
+	cmd, err := api.ASAdd("events_as", []string{"10.0.0.1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = api.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = api.ASDel("events_as")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = api.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	// every table the library monitors has its object
+	if recorded := sets.wait(t, 2); assert.Equal(t, 2, len(recorded)) {
+		old, new := recorded[0].AddressSet()
+		assert.Nil(t, old)
+		if assert.NotNil(t, new) {
+			assert.Equal(t, "events_as", new.Name)
+			assert.Equal(t, []string{"10.0.0.1"}, new.Addresses)
+		}
+		old, new = recorded[1].AddressSet()
+		assert.Nil(t, new)
+		if assert.NotNil(t, old) {
+			assert.Equal(t, "events_as", old.Name)
+		}
+	}
+}
+
+func TestSubscribeQueue(t *testing.T) {
+	api := getOVNClient(DBNB)
+	defer api.Close()
+
+	release := make(chan struct{})
+	newest, oldest := &eventRecorder{}, &eventRecorder{}
+	queued := func(r *eventRecorder, started chan struct{}) EventHandler {
+		return func(event Event) {
+			r.handle(event)
+			if len(r.recorded()) == 1 {
+				close(started)
+			}
+			<-release
+		}
+	}
+	newestStarted, oldestStarted := make(chan struct{}), make(chan struct{})
+	newestSub := api.SubscribeQueue(TableLogicalSwitchPort, queued(newest, newestStarted),
+		QueueConfig{Size: 1, Overflow: OverflowDropNewest})
+	oldestSub := api.SubscribeQueue(TableLogicalSwitchPort, queued(oldest, oldestStarted),
+		QueueConfig{Size: 1, Overflow: OverflowDropOldest})
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = api.LSPAdd(LSW, LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = api.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := api.LSDel(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = api.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+	// the handlers are busy with the first event
+	<-newestStarted
+	<-oldestStarted
+
+	// the monitor goes on with the handlers busy, the queues overflow
+	for _, lsp := range []string{PORT_TEST_LSP1, PORT_TEST_LSP2} {
+		cmd, err = api.LSPAdd(LSW, lsp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = api.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := newestSub.Stats()
+	assert.Equal(t, uint64(1), stats.Dropped)
+	assert.Equal(t, 1, stats.Queued)
+	stats = oldestSub.Stats()
+	assert.Equal(t, uint64(1), stats.Dropped)
+	assert.Equal(t, 1, stats.Queued)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return newestSub.Stats().Handled == 2 && oldestSub.Stats().Handled == 2
+	}, time.Second, 10*time.Millisecond)
+	recorded := newest.recorded()
+	assert.Equal(t, PORT_TEST_LSP1, recorded[len(recorded)-1].New.(*LogicalSwitchPort).Name)
+	recorded = oldest.recorded()
+	assert.Equal(t, PORT_TEST_LSP2, recorded[len(recorded)-1].New.(*LogicalSwitchPort).Name)
+}
+
+func TestSubscribeQueueDefaultPolicy(t *testing.T) {
+	api := getOVNClient(DBNB)
+	defer api.Close()
+
+	// a bounded queue without policy drops the events that overflow it
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	sub := api.SubscribeQueue(TableLogicalSwitchPort, func(event Event) {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+	}, QueueConfig{Size: 1})
+	defer sub.Unsubscribe()
+
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := api.LSDel(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = api.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+	for i, lsp := range []string{LSP, PORT_TEST_LSP1, PORT_TEST_LSP2} {
+		cmd, err = api.LSPAdd(LSW, lsp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = api.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			<-started
+		}
+	}
+	stats := sub.Stats()
+	assert.Equal(t, uint64(1), stats.Dropped)
+	assert.Equal(t, uint64(0), stats.Delayed)
+	close(release)
+}
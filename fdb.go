@@ -0,0 +1,143 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"github.com/ebay/libovsdb"
+)
+
+// FDB ovnsb item, a MAC address learnt on a port, which OVN 21.03 added
+type FDB struct {
+	UUID    string
+	MAC     string
+	DpKey   int
+	PortKey int
+}
+
+// requireFDB returns ErrorSchema if the database has no FDB table, ErrorNotMonitored if
+// it is not monitored
+func (odbi *ovndb) requireFDB() error {
+	schema, _ := odbi.getSchema()
+	if _, ok := schema.Tables[TableFDB]; !ok {
+		return errorf(ErrorSchema, "database %s has no FDB table", odbi.db)
+	}
+	return odbi.requireTable(TableFDB)
+}
+
+// fdbListFunc returns the FDB entries f is true for, f is called without the cache
+// lock held so that it may call the client
+func (odbi *ovndb) fdbListFunc(f func(*FDB) bool) ([]*FDB, error) {
+	if err := odbi.requireFDB(); err != nil {
+		return nil, err
+	}
+	fdbs := func() []*FDB {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+
+		fdbs := make([]*FDB, 0, len(odbi.cache[TableFDB]))
+		for uuid := range odbi.cache[TableFDB] {
+			fdbs = append(fdbs, odbi.rowToFDB(uuid))
+		}
+		return fdbs
+	}()
+
+	var listFDB []*FDB
+	for _, fdb := range fdbs {
+		if f(fdb) {
+			listFDB = append(listFDB, fdb)
+		}
+	}
+	return listFDB, nil
+}
+
+func (odbi *ovndb) fdbListImp() ([]*FDB, error) {
+	return odbi.fdbListFunc(func(*FDB) bool { return true })
+}
+
+func (odbi *ovndb) fdbListByDatapathImp(dpKey int) ([]*FDB, error) {
+	if err := odbi.requireColumns(TableFDB, "dp_key"); err != nil {
+		return nil, err
+	}
+	return odbi.fdbListFunc(func(fdb *FDB) bool { return fdb.DpKey == dpKey })
+}
+
+func (odbi *ovndb) fdbListByPortImp(dpKey, portKey int) ([]*FDB, error) {
+	if err := odbi.requireColumns(TableFDB, "dp_key", "port_key"); err != nil {
+		return nil, err
+	}
+	return odbi.fdbListFunc(func(fdb *FDB) bool { return fdb.DpKey == dpKey && fdb.PortKey == portKey })
+}
+
+func (odbi *ovndb) fdbDelImp(uuid string) (*OvnCommand, error) {
+	if err := odbi.requireFDB(); err != nil {
+		return nil, err
+	}
+	found := func() bool {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+		_, ok := odbi.cache[TableFDB][uuid]
+		return ok
+	}()
+	if !found {
+		return nil, ErrorNotFound
+	}
+	return odbi.fdbDelete([]*FDB{{UUID: uuid}}), nil
+}
+
+// fdbDelFuncImp deletes the FDB entries f is true for, ErrorNoChanges if there is none
+func (odbi *ovndb) fdbDelFuncImp(f func(*FDB) bool) (*OvnCommand, error) {
+	listFDB, err := odbi.fdbListFunc(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(listFDB) == 0 {
+		return nil, ErrorNoChanges
+	}
+	return odbi.fdbDelete(listFDB), nil
+}
+
+func (odbi *ovndb) fdbDelete(listFDB []*FDB) *OvnCommand {
+	operations := make([]libovsdb.Operation, 0, len(listFDB))
+	for _, fdb := range listFDB {
+		condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(fdb.UUID))
+		deleteOp := libovsdb.Operation{
+			Op:    opDelete,
+			Table: TableFDB,
+			Where: []interface{}{condition},
+		}
+		operations = append(operations, deleteOp)
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}
+}
+
+func (odbi *ovndb) rowToFDB(uuid string) *FDB {
+	cacheFDB, ok := odbi.cache[TableFDB][uuid]
+	if !ok {
+		return nil
+	}
+
+	mac, _ := cacheFDB.Fields["mac"].(string)
+	dpKey, _ := cacheFDB.Fields["dp_key"].(int)
+	portKey, _ := cacheFDB.Fields["port_key"].(int)
+
+	return &FDB{
+		UUID:    uuid,
+		MAC:     mac,
+		DpKey:   dpKey,
+		PortKey: portKey,
+	}
+}
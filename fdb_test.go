@@ -0,0 +1,119 @@
+package goovn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ovsdbtest "github.com/ebay/go-ovn/testing"
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFDB(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	defer sbTransact(t, ovndbapi, libovsdb.Operation{Op: opDelete, Table: TableFDB, Where: []interface{}{}})
+	entries := []struct {
+		mac     string
+		dpKey   int
+		portKey int
+	}{
+		{"00:00:00:00:02:01", 1, 1},
+		{"00:00:00:00:02:02", 1, 2},
+		{"00:00:00:00:02:01", 2, 1},
+	}
+	var operations []libovsdb.Operation
+	for _, entry := range entries {
+		row := make(OVNRow)
+		row["mac"] = entry.mac
+		row["dp_key"] = entry.dpKey
+		row["port_key"] = entry.portKey
+		operations = append(operations, libovsdb.Operation{Op: opInsert, Table: TableFDB, Row: row})
+	}
+	sbTransact(t, ovndbapi, operations...)
+
+	fdbs, err := ovndbapi.FDBList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(fdbs))
+	fdbs, err = ovndbapi.FDBListByDatapath(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(fdbs))
+	fdbs, err = ovndbapi.FDBListByPort(1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(fdbs)) {
+		assert.Equal(t, "00:00:00:00:02:02", fdbs[0].MAC)
+	}
+
+	cmd, err := ovndbapi.FDBDelFunc(func(fdb *FDB) bool {
+		return fdb.MAC == "00:00:00:00:02:01"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ovndbapi.FDBDelFunc(func(fdb *FDB) bool {
+		return fdb.MAC == "00:00:00:00:02:01"
+	})
+	assert.True(t, isError(err, ErrorNoChanges), err)
+
+	fdbs, err = ovndbapi.FDBList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(fdbs)) {
+		cmd, err = ovndbapi.FDBDel(fdbs[0].UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ovndbapi.FDBDel(fdbs[0].UUID)
+		assert.True(t, isError(err, ErrorNotFound), err)
+	}
+}
+
+func TestFDBOldSchema(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, OVNSB_SOCKET)
+	srv, err := ovsdbtest.NewServer(ovsdbtest.NBSchema, schemaWithout(t, ovsdbtest.SBSchema, TableFDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	if _, err = srv.Listen("unix", socket); err != nil {
+		t.Fatal(err)
+	}
+
+	// the table is left out of the monitor
+	api, err := NewClient(&Config{Db: DBSB, Addr: UNIX + ":" + socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	_, monitored := api.(*ovndb).tableCols[TableFDB]
+	assert.False(t, monitored)
+	_, monitored = api.(*ovndb).tableCols[TableMACBinding]
+	assert.True(t, monitored)
+	_, err = api.FDBList()
+	assert.True(t, isError(err, ErrorSchema), err)
+	_, err = api.FDBDel("8e5b1e2a-4a38-4d4b-8f3c-1a2b3c4d5e6f")
+	assert.True(t, isError(err, ErrorSchema), err)
+}
@@ -16,6 +16,13 @@
 
 package goovn
 
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ebay/libovsdb"
+)
+
 // GatewayChassis ovnnb item
 type GatewayChassis struct {
 	UUID        string
@@ -25,3 +32,161 @@ type GatewayChassis struct {
 	Options     map[interface{}]interface{}
 	ExternalID  map[interface{}]interface{}
 }
+
+// Priority range of gateway chassis, the highest is active
+const (
+	gatewayChassisMinPriority = 0
+	gatewayChassisMaxPriority = 32767
+)
+
+// lrpGatewayChassisUUIDs returns the UUID of lrp and the gateway chassis it references
+func (odbi *ovndb) lrpGatewayChassisUUIDs(lrp string) (string, []string, error) {
+	if err := odbi.requireColumns(TableLogicalRouterPort, "name", "gateway_chassis"); err != nil {
+		return "", nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for uuid, drows := range odbi.cache[TableLogicalRouterPort] {
+		if rlrp, ok := drows.Fields["name"].(string); ok && rlrp == lrp {
+			switch gc := drows.Fields["gateway_chassis"].(type) {
+			case libovsdb.UUID:
+				return uuid, []string{gc.GoUUID}, nil
+			case libovsdb.OvsSet:
+				return uuid, odbi.ConvertGoSetToStringArray(gc), nil
+			}
+			return uuid, nil, nil
+		}
+	}
+	return "", nil, ErrorNotFound
+}
+
+func (odbi *ovndb) lrpGatewayChassisAddImp(lrp, chassisName string, priority int) (*OvnCommand, error) {
+	if len(chassisName) == 0 {
+		return nil, fmt.Errorf("chassis name cannot be empty")
+	}
+	if priority < gatewayChassisMinPriority || priority > gatewayChassisMaxPriority {
+		return nil, errorf(ErrorOption, "priority %d out of range [%d, %d]", priority,
+			gatewayChassisMinPriority, gatewayChassisMaxPriority)
+	}
+	gcs, err := odbi.lrpGatewayChassisListImp(lrp)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["priority"] = priority
+	for _, gc := range gcs {
+		if gc.ChassisName == chassisName {
+			// like ovn-nbctl lrp-set-gateway-chassis, only the priority changes
+			condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(gc.UUID))
+			updateOp := libovsdb.Operation{
+				Op:    opUpdate,
+				Table: TableGatewayChassis,
+				Row:   row,
+				Where: []interface{}{condition},
+			}
+			operations := []libovsdb.Operation{updateOp}
+			return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+		}
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row["name"] = lrp + "-" + chassisName
+	row["chassis_name"] = chassisName
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableGatewayChassis,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(namedUUID)})
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("gateway_chassis", opInsert, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", lrp)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalRouterPort,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{insertOp, mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lrpGatewayChassisDelImp(lrp, chassisName string) (*OvnCommand, error) {
+	gcs, err := odbi.lrpGatewayChassisListImp(lrp)
+	if err != nil {
+		return nil, err
+	}
+	for _, gc := range gcs {
+		if gc.ChassisName != chassisName {
+			continue
+		}
+		// the Gateway_Chassis row is garbage collected once no port references it
+		mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(gc.UUID)})
+		if err != nil {
+			return nil, err
+		}
+		mutation := libovsdb.NewMutation("gateway_chassis", opDelete, mutateSet)
+		condition := libovsdb.NewCondition("name", "==", lrp)
+		mutateOp := libovsdb.Operation{
+			Op:        opMutate,
+			Table:     TableLogicalRouterPort,
+			Mutations: []interface{}{mutation},
+			Where:     []interface{}{condition},
+		}
+		operations := []libovsdb.Operation{mutateOp}
+		return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+	}
+	return nil, ErrorNotFound
+}
+
+// lrpGatewayChassisListImp returns the gateway chassis of lrp by decreasing priority
+func (odbi *ovndb) lrpGatewayChassisListImp(lrp string) ([]*GatewayChassis, error) {
+	_, uuids, err := odbi.lrpGatewayChassisUUIDs(lrp)
+	if err != nil {
+		return nil, err
+	}
+	if err := odbi.requireColumns(TableGatewayChassis, "chassis_name", "priority"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	gcs := make([]*GatewayChassis, 0, len(uuids))
+	for _, uuid := range uuids {
+		if _, ok := odbi.cache[TableGatewayChassis][uuid]; ok {
+			gcs = append(gcs, odbi.rowToGatewayChassis(uuid))
+		}
+	}
+	sort.Slice(gcs, func(i, j int) bool {
+		if gcs[i].Priority != gcs[j].Priority {
+			return gcs[i].Priority > gcs[j].Priority
+		}
+		return gcs[i].Name < gcs[j].Name
+	})
+	return gcs, nil
+}
+
+func (odbi *ovndb) rowToGatewayChassis(uuid string) *GatewayChassis {
+	name, _ := odbi.cache[TableGatewayChassis][uuid].Fields["name"].(string)
+	chassisName, _ := odbi.cache[TableGatewayChassis][uuid].Fields["chassis_name"].(string)
+	priority, _ := odbi.cache[TableGatewayChassis][uuid].Fields["priority"].(int)
+	options, _ := odbi.cache[TableGatewayChassis][uuid].Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := odbi.cache[TableGatewayChassis][uuid].Fields["external_ids"].(libovsdb.OvsMap)
+	return &GatewayChassis{
+		UUID:        uuid,
+		Name:        name,
+		ChassisName: chassisName,
+		Priority:    priority,
+		Options:     options.GoMap,
+		ExternalID:  extIDs.GoMap,
+	}
+}
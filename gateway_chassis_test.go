@@ -0,0 +1,111 @@
+package goovn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	GC_LR      = "gc_lr"
+	GC_LRP     = "gc_lrp"
+	GC_CHASSIS = "gc_chassis"
+	GC_BACKUP  = "gc_backup"
+)
+
+func TestLRPGatewayChassis(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err := ovndbapi.LRAdd(GC_LR, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LRPAdd(GC_LR, GC_LRP, "54:54:54:54:54:55", []string{"192.168.1.1/24"}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.LRDel(GC_LR)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	for chassis, priority := range map[string]int{GC_CHASSIS: 10, GC_BACKUP: 5} {
+		cmd, err = ovndbapi.LRPGatewayChassisAdd(GC_LRP, chassis, priority)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	gcs, err := ovndbapi.LRPGatewayChassisList(GC_LRP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(gcs))
+	assert.Equal(t, GC_CHASSIS, gcs[0].ChassisName)
+	assert.Equal(t, GC_LRP+"-"+GC_CHASSIS, gcs[0].Name)
+	assert.Equal(t, 10, gcs[0].Priority)
+	assert.Equal(t, GC_BACKUP, gcs[1].ChassisName)
+
+	lrps, err := ovndbapi.LRPList(GC_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.ElementsMatch(t, []string{gcs[0].UUID, gcs[1].UUID}, lrps[0].GatewayChassis)
+
+	// adding an existing chassis changes its priority
+	cmd, err = ovndbapi.LRPGatewayChassisAdd(GC_LRP, GC_BACKUP, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcs, err = ovndbapi.LRPGatewayChassisList(GC_LRP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(gcs))
+	assert.Equal(t, GC_BACKUP, gcs[0].ChassisName)
+	assert.Equal(t, 20, gcs[0].Priority)
+
+	_, err = ovndbapi.LRPGatewayChassisAdd(GC_LRP, GC_CHASSIS, 32768)
+	assert.True(t, isError(err, ErrorOption), err)
+
+	cmd, err = ovndbapi.LRPGatewayChassisDel(GC_LRP, GC_BACKUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcs, err = ovndbapi.LRPGatewayChassisList(GC_LRP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(gcs))
+	assert.Equal(t, GC_CHASSIS, gcs[0].ChassisName)
+
+	_, err = ovndbapi.LRPGatewayChassisDel(GC_LRP, GC_BACKUP)
+	assert.Equal(t, ErrorNotFound, err)
+	_, err = ovndbapi.LRPGatewayChassisList(FAKENOROUTER)
+	assert.Equal(t, ErrorNotFound, err)
+}
@@ -37,7 +37,9 @@ func (odbi *ovndb) addGlobalTableRowImp(options map[string]string, table string)
 
 	row["options"] = optionsMap
 
-	if uuid := odbi.getRowUUID(table, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(table, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
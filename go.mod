@@ -3,6 +3,7 @@ module github.com/ebay/go-ovn
 go 1.12
 
 require (
+	github.com/cenkalti/rpc2 v0.0.0-20170726070524-c51a77e5f664
 	github.com/ebay/libovsdb v0.0.0-20190718202342-e49b8c4e1142
 	github.com/google/uuid v1.1.1
 	github.com/stretchr/testify v1.4.0
@@ -0,0 +1,293 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ebay/libovsdb"
+)
+
+// HAChassis ovnnb item
+type HAChassis struct {
+	UUID        string
+	ChassisName string
+	Priority    int
+	ExternalID  map[interface{}]interface{}
+}
+
+// HAChassisGroup ovnnb item, its chassis are sorted by decreasing priority
+type HAChassisGroup struct {
+	UUID       string
+	Name       string
+	HAChassis  []*HAChassis
+	ExternalID map[interface{}]interface{}
+}
+
+func (odbi *ovndb) haChassisGroupAddImp(group string, external_ids map[string]string) (*OvnCommand, error) {
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["name"] = group
+	if uuid, err := odbi.getRowUUID(TableHAChassisGroup, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
+		return nil, ErrorExist
+	}
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableHAChassisGroup,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+	operations := []libovsdb.Operation{insertOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) haChassisGroupDelImp(group string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = group
+	if uuid, err := odbi.getRowUUID(TableHAChassisGroup, row); err != nil {
+		return nil, err
+	} else if len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+	// its HA_Chassis rows are garbage collected with it
+	condition := libovsdb.NewCondition("name", "==", group)
+	deleteOp := libovsdb.Operation{
+		Op:    opDelete,
+		Table: TableHAChassisGroup,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{deleteOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) haChassisGroupGetImp(group string) (*HAChassisGroup, error) {
+	if err := odbi.requireColumns(TableHAChassisGroup, "name", "ha_chassis"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for uuid, drows := range odbi.cache[TableHAChassisGroup] {
+		if rgroup, ok := drows.Fields["name"].(string); ok && rgroup == group {
+			return odbi.rowToHAChassisGroup(uuid), nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (odbi *ovndb) haChassisGroupListImp() ([]*HAChassisGroup, error) {
+	if err := odbi.requireColumns(TableHAChassisGroup, "ha_chassis"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	groups := make([]*HAChassisGroup, 0, len(odbi.cache[TableHAChassisGroup]))
+	for uuid := range odbi.cache[TableHAChassisGroup] {
+		groups = append(groups, odbi.rowToHAChassisGroup(uuid))
+	}
+	return groups, nil
+}
+
+func (odbi *ovndb) haChassisGroupAddChassisImp(group, chassisName string, priority int) (*OvnCommand, error) {
+	if len(chassisName) == 0 {
+		return nil, fmt.Errorf("chassis name cannot be empty")
+	}
+	if priority < gatewayChassisMinPriority || priority > gatewayChassisMaxPriority {
+		return nil, errorf(ErrorOption, "priority %d out of range [%d, %d]", priority,
+			gatewayChassisMinPriority, gatewayChassisMaxPriority)
+	}
+	hg, err := odbi.haChassisGroupGetImp(group)
+	if err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	row["priority"] = priority
+	for _, hc := range hg.HAChassis {
+		if hc.ChassisName == chassisName {
+			// like ovn-nbctl ha-chassis-group-add-chassis, only the priority changes
+			condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(hc.UUID))
+			updateOp := libovsdb.Operation{
+				Op:    opUpdate,
+				Table: TableHAChassis,
+				Row:   row,
+				Where: []interface{}{condition},
+			}
+			operations := []libovsdb.Operation{updateOp}
+			return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+		}
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row["chassis_name"] = chassisName
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableHAChassis,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(namedUUID)})
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("ha_chassis", opInsert, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", group)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableHAChassisGroup,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{insertOp, mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) haChassisGroupDelChassisImp(group, chassisName string) (*OvnCommand, error) {
+	hg, err := odbi.haChassisGroupGetImp(group)
+	if err != nil {
+		return nil, err
+	}
+	for _, hc := range hg.HAChassis {
+		if hc.ChassisName != chassisName {
+			continue
+		}
+		// the HA_Chassis row is garbage collected once no group references it
+		mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(hc.UUID)})
+		if err != nil {
+			return nil, err
+		}
+		mutation := libovsdb.NewMutation("ha_chassis", opDelete, mutateSet)
+		condition := libovsdb.NewCondition("name", "==", group)
+		mutateOp := libovsdb.Operation{
+			Op:        opMutate,
+			Table:     TableHAChassisGroup,
+			Mutations: []interface{}{mutation},
+			Where:     []interface{}{condition},
+		}
+		operations := []libovsdb.Operation{mutateOp}
+		return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+	}
+	return nil, ErrorNotFound
+}
+
+// haChassisGroupSetImp sets the ha_chassis_group of the port named port in table,
+// clearing it if group is empty
+func (odbi *ovndb) haChassisGroupSetImp(table, port, group string) (*OvnCommand, error) {
+	row := make(OVNRow)
+	row["name"] = port
+	if uuid, err := odbi.getRowUUID(table, row); err != nil {
+		return nil, err
+	} else if len(uuid) == 0 {
+		return nil, ErrorNotFound
+	}
+
+	row = make(OVNRow)
+	if len(group) == 0 {
+		row["ha_chassis_group"] = libovsdb.OvsSet{GoSet: []interface{}{}}
+	} else {
+		hg, err := odbi.haChassisGroupGetImp(group)
+		if err != nil {
+			return nil, err
+		}
+		row["ha_chassis_group"] = stringToGoUUID(hg.UUID)
+	}
+	condition := libovsdb.NewCondition("name", "==", port)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: table,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lrpSetHAChassisGroupImp(lrp, group string) (*OvnCommand, error) {
+	return odbi.haChassisGroupSetImp(TableLogicalRouterPort, lrp, group)
+}
+
+func (odbi *ovndb) lspSetHAChassisGroupImp(lsp, group string) (*OvnCommand, error) {
+	lp, err := odbi.lspGetImp(lsp)
+	if err != nil {
+		return nil, err
+	}
+	if len(group) > 0 && lp.Type != "external" {
+		return nil, errorf(ErrorOption, "port %s of type %q is not external", lsp, lp.Type)
+	}
+	return odbi.haChassisGroupSetImp(TableLogicalSwitchPort, lsp, group)
+}
+
+func (odbi *ovndb) rowToHAChassisGroup(uuid string) *HAChassisGroup {
+	name, _ := odbi.cache[TableHAChassisGroup][uuid].Fields["name"].(string)
+	extIDs, _ := odbi.cache[TableHAChassisGroup][uuid].Fields["external_ids"].(libovsdb.OvsMap)
+	hg := &HAChassisGroup{
+		UUID:       uuid,
+		Name:       name,
+		ExternalID: extIDs.GoMap,
+	}
+
+	var uuids []string
+	switch hcs := odbi.cache[TableHAChassisGroup][uuid].Fields["ha_chassis"].(type) {
+	case libovsdb.UUID:
+		uuids = []string{hcs.GoUUID}
+	case libovsdb.OvsSet:
+		uuids = odbi.ConvertGoSetToStringArray(hcs)
+	}
+	hg.HAChassis = make([]*HAChassis, 0, len(uuids))
+	for _, hcUUID := range uuids {
+		if _, ok := odbi.cache[TableHAChassis][hcUUID]; ok {
+			hg.HAChassis = append(hg.HAChassis, odbi.rowToHAChassis(hcUUID))
+		}
+	}
+	sort.Slice(hg.HAChassis, func(i, j int) bool {
+		if hg.HAChassis[i].Priority != hg.HAChassis[j].Priority {
+			return hg.HAChassis[i].Priority > hg.HAChassis[j].Priority
+		}
+		return hg.HAChassis[i].ChassisName < hg.HAChassis[j].ChassisName
+	})
+	return hg
+}
+
+func (odbi *ovndb) rowToHAChassis(uuid string) *HAChassis {
+	chassisName, _ := odbi.cache[TableHAChassis][uuid].Fields["chassis_name"].(string)
+	priority, _ := odbi.cache[TableHAChassis][uuid].Fields["priority"].(int)
+	extIDs, _ := odbi.cache[TableHAChassis][uuid].Fields["external_ids"].(libovsdb.OvsMap)
+	return &HAChassis{
+		UUID:        uuid,
+		ChassisName: chassisName,
+		Priority:    priority,
+		ExternalID:  extIDs.GoMap,
+	}
+}
@@ -0,0 +1,200 @@
+package goovn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	HA_GROUP       = "ha_group"
+	HA_LR          = "ha_lr"
+	HA_LRP         = "ha_lrp"
+	HA_LS          = "ha_ls"
+	HA_LSP         = "ha_lsp"
+	HA_CHASSIS     = "ha_chassis"
+	HA_CHASSIS_BAK = "ha_chassis_bak"
+)
+
+func TestHAChassisGroup(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	cmd, err := ovndbapi.HAChassisGroupAdd(HA_GROUP, map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ovndbapi.HAChassisGroupAdd(HA_GROUP, nil)
+	assert.Equal(t, ErrorExist, err)
+
+	for chassis, priority := range map[string]int{HA_CHASSIS: 20, HA_CHASSIS_BAK: 10} {
+		cmd, err = ovndbapi.HAChassisGroupAddChassis(HA_GROUP, chassis, priority)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	hg, err := ovndbapi.HAChassisGroupGet(HA_GROUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, HA_GROUP, hg.Name)
+	assert.Equal(t, "bar", hg.ExternalID["foo"])
+	if assert.Equal(t, 2, len(hg.HAChassis)) {
+		assert.Equal(t, HA_CHASSIS, hg.HAChassis[0].ChassisName)
+		assert.Equal(t, 20, hg.HAChassis[0].Priority)
+		assert.Equal(t, HA_CHASSIS_BAK, hg.HAChassis[1].ChassisName)
+	}
+
+	// adding an existing chassis changes its priority
+	cmd, err = ovndbapi.HAChassisGroupAddChassis(HA_GROUP, HA_CHASSIS_BAK, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hgs, err := ovndbapi.HAChassisGroupList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(hgs)) && assert.Equal(t, 2, len(hgs[0].HAChassis)) {
+		assert.Equal(t, HA_CHASSIS_BAK, hgs[0].HAChassis[0].ChassisName)
+		assert.Equal(t, 30, hgs[0].HAChassis[0].Priority)
+	}
+
+	cmd, err = ovndbapi.HAChassisGroupDelChassis(HA_GROUP, HA_CHASSIS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hg, err = ovndbapi.HAChassisGroupGet(HA_GROUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(hg.HAChassis))
+	_, err = ovndbapi.HAChassisGroupDelChassis(HA_GROUP, HA_CHASSIS)
+	assert.Equal(t, ErrorNotFound, err)
+
+	// attach the group to a distributed gateway port and an external port
+	cmds := make([]*OvnCommand, 0)
+	cmd, err = ovndbapi.LRAdd(HA_LR, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LRPAdd(HA_LR, HA_LRP, "54:54:54:54:54:56", []string{"192.168.2.1/24"}, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSAdd(HA_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSPAdd(HA_LS, HA_LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, err = ovndbapi.LRPSetHAChassisGroup(HA_LRP, HA_GROUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lrps, err := ovndbapi.LRPList(HA_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, hg.UUID, lrps[0].HAChassisGroup)
+
+	_, err = ovndbapi.LSPSetHAChassisGroup(HA_LSP, HA_GROUP)
+	assert.True(t, isError(err, ErrorOption), err)
+	cmd, err = ovndbapi.LSPSetType(HA_LSP, "external")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = ovndbapi.LSPSetHAChassisGroup(HA_LSP, HA_GROUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsp, err := ovndbapi.LSPGet(HA_LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "external", lsp.Type)
+	assert.Equal(t, hg.UUID, lsp.HAChassisGroup)
+
+	// detach the group
+	cmds = make([]*OvnCommand, 0)
+	cmd, err = ovndbapi.LRPSetHAChassisGroup(HA_LRP, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSPSetHAChassisGroup(HA_LSP, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lsp, err = ovndbapi.LSPGet(HA_LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "", lsp.HAChassisGroup)
+
+	cmds = make([]*OvnCommand, 0)
+	cmd, err = ovndbapi.LRDel(HA_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LSDel(HA_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.HAChassisGroupDel(HA_GROUP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ovndbapi.HAChassisGroupGet(HA_GROUP)
+	assert.Equal(t, ErrorNotFound, err)
+}
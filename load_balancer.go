@@ -69,7 +69,9 @@ func (odbi *ovndb) lbAddImp(name string, vipPort string, protocol string, addrs
 	row := make(OVNRow)
 	row["name"] = name
 
-	if uuid := odbi.getRowUUID(TableLoadBalancer, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLoadBalancer, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -106,7 +108,10 @@ func (odbi *ovndb) lbDelImp(name string) (*OvnCommand, error) {
 	// Also delete references from Logical switches
 	row := make(OVNRow)
 	row["name"] = name
-	lbuuid := odbi.getRowUUID(TableLoadBalancer, row)
+	lbuuid, err := odbi.getRowUUID(TableLoadBalancer, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lbuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -137,6 +142,9 @@ func (odbi *ovndb) lbDelImp(name string) (*OvnCommand, error) {
 }
 
 func (odbi *ovndb) lbGetImp(name string) ([]*LoadBalancer, error) {
+	if err := odbi.requireColumns(TableLoadBalancer, "name"); err != nil {
+		return nil, err
+	}
 	var listLB []*LoadBalancer
 
 	odbi.cachemutex.RLock()
@@ -181,12 +189,17 @@ func (odbi *ovndb) rowToLB(uuid string) (*LoadBalancer, error) {
 		return nil, ErrorSchema
 	}
 
+	protocol, _ := cacheLoadBalancer.Fields["protocol"].(string)
+	name, _ := cacheLoadBalancer.Fields["name"].(string)
+	vips, _ := cacheLoadBalancer.Fields["vips"].(libovsdb.OvsMap)
+	extIDs, _ := cacheLoadBalancer.Fields["external_ids"].(libovsdb.OvsMap)
+
 	lb := &LoadBalancer{
 		UUID:       uuid,
-		protocol:   cacheLoadBalancer.Fields["protocol"].(string),
-		Name:       cacheLoadBalancer.Fields["name"].(string),
-		vips:       cacheLoadBalancer.Fields["vips"].(libovsdb.OvsMap).GoMap,
-		ExternalID: cacheLoadBalancer.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		protocol:   protocol,
+		Name:       name,
+		vips:       vips.GoMap,
+		ExternalID: extIDs.GoMap,
 	}
 
 	if fields, ok := cacheLoadBalancer.Fields["selection_fields"].(string); ok {
@@ -0,0 +1,183 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ebay/libovsdb"
+)
+
+// Pipelines of logical flows
+const (
+	LogicalFlowPipelineIngress = "ingress"
+	LogicalFlowPipelineEgress  = "egress"
+)
+
+// LogicalFlow ovnsb item, LogicalDatapath is the uuid of its DatapathBinding, or else
+// LogicalDpGroup the uuid of the LogicalDPGroup of its datapaths
+type LogicalFlow struct {
+	UUID            string
+	LogicalDatapath string
+	LogicalDpGroup  string
+	Pipeline        string
+	TableID         int
+	Priority        int
+	Match           string
+	Actions         string
+	ExternalID      map[interface{}]interface{}
+}
+
+// LogicalDPGroup ovnsb item, Datapaths are the uuids of DatapathBinding
+type LogicalDPGroup struct {
+	UUID      string
+	Datapaths []string
+}
+
+// logicalFlowListImp returns the flows matching all of datapath, pipeline, tableID, priority
+// and containing match, "" and -1 match any, sorted like ovn-sbctl lflow-list
+func (odbi *ovndb) logicalFlowListImp(datapath, pipeline string, tableID, priority int, match string) ([]*LogicalFlow, error) {
+	switch pipeline {
+	case "", LogicalFlowPipelineIngress, LogicalFlowPipelineEgress:
+	default:
+		return nil, errorf(ErrorOption, "unknown pipeline %q", pipeline)
+	}
+	if err := odbi.requireTable(TableLogicalFlow); err != nil {
+		return nil, err
+	}
+	if err := odbi.requireColumns(TableLogicalFlow, "logical_datapath", "pipeline", "table_id", "priority", "match"); err != nil {
+		return nil, err
+	}
+	// flows shared by datapaths have a group of them since OVN 21.03
+	schema, _ := odbi.getSchema()
+	_, groups := schema.Tables[TableLogicalFlow].Columns["logical_dp_group"]
+	if len(datapath) > 0 && groups {
+		if err := odbi.requireColumns(TableLogicalFlow, "logical_dp_group"); err != nil {
+			return nil, err
+		}
+		if err := odbi.requireTable(TableLogicalDPGroup); err != nil {
+			return nil, err
+		}
+		if err := odbi.requireColumns(TableLogicalDPGroup, "datapaths"); err != nil {
+			return nil, err
+		}
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var listFlow []*LogicalFlow
+	for uuid := range odbi.cache[TableLogicalFlow] {
+		flow := odbi.rowToLogicalFlow(uuid)
+		if (len(datapath) == 0 || odbi.logicalFlowHasDatapath(flow, datapath)) &&
+			(len(pipeline) == 0 || flow.Pipeline == pipeline) &&
+			(tableID == -1 || flow.TableID == tableID) &&
+			(priority == -1 || flow.Priority == priority) &&
+			strings.Contains(flow.Match, match) {
+			listFlow = append(listFlow, flow)
+		}
+	}
+	sort.Slice(listFlow, func(i, j int) bool {
+		a, b := listFlow[i], listFlow[j]
+		if a.LogicalDatapath != b.LogicalDatapath {
+			return a.LogicalDatapath < b.LogicalDatapath
+		}
+		if a.Pipeline != b.Pipeline {
+			// ingress first
+			return a.Pipeline == LogicalFlowPipelineIngress
+		}
+		if a.TableID != b.TableID {
+			return a.TableID < b.TableID
+		}
+		if a.Priority != b.Priority {
+			return a.Priority > b.Priority
+		}
+		return a.Match < b.Match
+	})
+	return listFlow, nil
+}
+
+// logicalFlowHasDatapath tells whether flow is the one of datapath or of a group with it
+func (odbi *ovndb) logicalFlowHasDatapath(flow *LogicalFlow, datapath string) bool {
+	if flow.LogicalDatapath == datapath {
+		return true
+	}
+	if len(flow.LogicalDpGroup) == 0 {
+		return false
+	}
+	group := odbi.rowToLogicalDPGroup(flow.LogicalDpGroup)
+	if group == nil {
+		return false
+	}
+	for _, dp := range group.Datapaths {
+		if dp == datapath {
+			return true
+		}
+	}
+	return false
+}
+
+func (odbi *ovndb) rowToLogicalFlow(uuid string) *LogicalFlow {
+	cacheLogicalFlow, ok := odbi.cache[TableLogicalFlow][uuid]
+	if !ok {
+		return nil
+	}
+
+	pipeline, _ := cacheLogicalFlow.Fields["pipeline"].(string)
+	tableID, _ := cacheLogicalFlow.Fields["table_id"].(int)
+	priority, _ := cacheLogicalFlow.Fields["priority"].(int)
+	match, _ := cacheLogicalFlow.Fields["match"].(string)
+	actions, _ := cacheLogicalFlow.Fields["actions"].(string)
+	extIDs, _ := cacheLogicalFlow.Fields["external_ids"].(libovsdb.OvsMap)
+
+	flow := &LogicalFlow{
+		UUID:       uuid,
+		Pipeline:   pipeline,
+		TableID:    tableID,
+		Priority:   priority,
+		Match:      match,
+		Actions:    actions,
+		ExternalID: extIDs.GoMap,
+	}
+	if datapath, ok := cacheLogicalFlow.Fields["logical_datapath"].(libovsdb.UUID); ok {
+		flow.LogicalDatapath = datapath.GoUUID
+	}
+	if group, ok := cacheLogicalFlow.Fields["logical_dp_group"].(libovsdb.UUID); ok {
+		flow.LogicalDpGroup = group.GoUUID
+	}
+	return flow
+}
+
+func (odbi *ovndb) rowToLogicalDPGroup(uuid string) *LogicalDPGroup {
+	cacheLogicalDPGroup, ok := odbi.cache[TableLogicalDPGroup][uuid]
+	if !ok {
+		return nil
+	}
+
+	group := &LogicalDPGroup{UUID: uuid}
+	switch datapaths := cacheLogicalDPGroup.Fields["datapaths"].(type) {
+	case libovsdb.UUID:
+		group.Datapaths = []string{datapaths.GoUUID}
+	case libovsdb.OvsSet:
+		for _, dp := range datapaths.GoSet {
+			if dpUUID, ok := dp.(libovsdb.UUID); ok {
+				group.Datapaths = append(group.Datapaths, dpUUID.GoUUID)
+			}
+		}
+	}
+	return group
+}
@@ -0,0 +1,139 @@
+package goovn
+
+import (
+	"testing"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogicalFlow(t *testing.T) {
+	ovndbapi := getOVNClientOnRequest()
+	defer ovndbapi.Close()
+
+	datapath := addDatapath(t, ovndbapi)
+	defer sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opDelete, Table: TableLogicalFlow, Where: []interface{}{}},
+		libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding,
+			Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", DP_KEY)}})
+
+	flows := []struct {
+		pipeline string
+		tableID  int
+		priority int
+		match    string
+	}{
+		{LogicalFlowPipelineEgress, 0, 100, "eth.src[40]"},
+		{LogicalFlowPipelineIngress, 0, 50, "eth.src == 00:00:00:00:00:01"},
+		{LogicalFlowPipelineIngress, 0, 100, "vlan.present"},
+		{LogicalFlowPipelineIngress, 1, 0, "1"},
+	}
+	var operations []libovsdb.Operation
+	for _, flow := range flows {
+		row := make(OVNRow)
+		row["logical_datapath"] = stringToGoUUID(datapath)
+		row["pipeline"] = flow.pipeline
+		row["table_id"] = flow.tableID
+		row["priority"] = flow.priority
+		row["match"] = flow.match
+		row["actions"] = "next;"
+		operations = append(operations, libovsdb.Operation{Op: opInsert, Table: TableLogicalFlow, Row: row})
+	}
+	sbTransact(t, ovndbapi, operations...)
+
+	lflows, err := ovndbapi.LogicalFlowList("", "", -1, -1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 4, len(lflows)) {
+		assert.Equal(t, "vlan.present", lflows[0].Match)
+		assert.Equal(t, "eth.src == 00:00:00:00:00:01", lflows[1].Match)
+		assert.Equal(t, "1", lflows[2].Match)
+		assert.Equal(t, LogicalFlowPipelineEgress, lflows[3].Pipeline)
+		assert.Equal(t, datapath, lflows[3].LogicalDatapath)
+		assert.Equal(t, "next;", lflows[3].Actions)
+	}
+
+	lflows, err = ovndbapi.LogicalFlowList(datapath, LogicalFlowPipelineIngress, 0, -1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(lflows))
+	lflows, err = ovndbapi.LogicalFlowList(datapath, "", -1, 100, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(lflows))
+	lflows, err = ovndbapi.LogicalFlowList("", "", -1, -1, "eth.src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(lflows))
+	lflows, err = ovndbapi.LogicalFlowList(FAKENOSWITCH, "", -1, -1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(lflows))
+	_, err = ovndbapi.LogicalFlowList("", "forward", -1, -1, "")
+	assert.True(t, isError(err, ErrorOption), err)
+}
+
+func TestLogicalFlowDPGroup(t *testing.T) {
+	ovndbapi := getOVNClientOnRequest()
+	defer ovndbapi.Close()
+
+	datapath := addDatapath(t, ovndbapi)
+	defer sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opDelete, Table: TableLogicalFlow, Where: []interface{}{}},
+		libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding,
+			Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", DP_KEY)}},
+		libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding,
+			Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", DP_KEY+1)}})
+
+	// a flow of both datapaths, the group is garbage collected with it
+	datapaths := libovsdb.OvsSet{GoSet: []interface{}{stringToGoUUID(datapath), stringToGoUUID("dp2")}}
+	sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opInsert, Table: TableDatapathBinding, Row: OVNRow{"tunnel_key": DP_KEY + 1},
+			UUIDName: "dp2"},
+		libovsdb.Operation{Op: opInsert, Table: TableLogicalDPGroup, Row: OVNRow{"datapaths": datapaths},
+			UUIDName: "group"},
+		libovsdb.Operation{Op: opInsert, Table: TableLogicalFlow, Row: OVNRow{
+			"logical_dp_group": stringToGoUUID("group"),
+			"pipeline":         LogicalFlowPipelineIngress,
+			"table_id":         0,
+			"priority":         0,
+			"match":            "1",
+			"actions":          "next;"}})
+
+	dps, err := ovndbapi.DatapathBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dp := range dps {
+		lflows, err := ovndbapi.LogicalFlowList(dp.UUID, "", -1, -1, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assert.Equal(t, 1, len(lflows)) {
+			assert.Equal(t, "", lflows[0].LogicalDatapath)
+			assert.NotEqual(t, "", lflows[0].LogicalDpGroup)
+		}
+	}
+	assert.Equal(t, 2, len(dps))
+
+	// datapaths are matched through the groups, which must be monitored too
+	cfg := buildOvnDbConfig(DBSB)
+	cfg.TableCols = map[string][]string{TableLogicalFlow: {}}
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+	_, err = api.LogicalFlowList(datapath, "", -1, -1, "")
+	assert.True(t, isError(err, ErrorNotMonitored), err)
+	lflows, err := api.LogicalFlowList("", "", -1, -1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(lflows))
+}
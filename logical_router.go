@@ -30,6 +30,7 @@ type LogicalRouter struct {
 
 	Ports        []string
 	StaticRoutes []string
+	Policies     []string
 	NAT          []string
 	LoadBalancer []string
 
@@ -54,7 +55,9 @@ func (odbi *ovndb) lrAddImp(name string, external_ids map[string]string) (*OvnCo
 		row["external_ids"] = oMap
 	}
 
-	if uuid := odbi.getRowUUID(TableLogicalRouter, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalRouter, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -81,6 +84,9 @@ func (odbi *ovndb) lrDelImp(name string) (*OvnCommand, error) {
 }
 
 func (odbi *ovndb) lrGetImp(name string) ([]*LogicalRouter, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name"); err != nil {
+		return nil, err
+	}
 	var lrList []*LogicalRouter
 
 	odbi.cachemutex.RLock()
@@ -105,11 +111,14 @@ func (odbi *ovndb) rowToLogicalRouter(uuid string) *LogicalRouter {
 	if !ok {
 		return nil
 	}
+	name, _ := cacheLogicalRouter.Fields["name"].(string)
+	options, _ := cacheLogicalRouter.Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := cacheLogicalRouter.Fields["external_ids"].(libovsdb.OvsMap)
 	lr := &LogicalRouter{
 		UUID:       uuid,
-		Name:       cacheLogicalRouter.Fields["name"].(string),
-		Options:    cacheLogicalRouter.Fields["options"].(libovsdb.OvsMap).GoMap,
-		ExternalID: cacheLogicalRouter.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Name:       name,
+		Options:    options.GoMap,
+		ExternalID: extIDs.GoMap,
 	}
 
 	if enabled, ok := cacheLogicalRouter.Fields["enabled"]; ok {
@@ -150,6 +159,15 @@ func (odbi *ovndb) rowToLogicalRouter(uuid string) *LogicalRouter {
 		}
 	}
 
+	if policies, ok := cacheLogicalRouter.Fields["policies"]; ok {
+		switch policies.(type) {
+		case libovsdb.UUID:
+			lr.Policies = []string{policies.(libovsdb.UUID).GoUUID}
+		case libovsdb.OvsSet:
+			lr.Policies = odbi.ConvertGoSetToStringArray(policies.(libovsdb.OvsSet))
+		}
+	}
+
 	if nats, ok := cacheLogicalRouter.Fields["nat"]; ok {
 		switch nats.(type) {
 		case libovsdb.UUID:
@@ -164,6 +182,9 @@ func (odbi *ovndb) rowToLogicalRouter(uuid string) *LogicalRouter {
 
 // Get all logical routers
 func (odbi *ovndb) lrListImp() ([]*LogicalRouter, error) {
+	if err := odbi.requireTable(TableLogicalRouter); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -184,7 +205,10 @@ func (odbi *ovndb) lrlbAddImp(lr string, lb string) (*OvnCommand, error) {
 	var operations []libovsdb.Operation
 	row := make(OVNRow)
 	row["name"] = lb
-	lbuuid := odbi.getRowUUID(TableLoadBalancer, row)
+	lbuuid, err := odbi.getRowUUID(TableLoadBalancer, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lbuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -196,7 +220,10 @@ func (odbi *ovndb) lrlbAddImp(lr string, lb string) (*OvnCommand, error) {
 	}
 	row = make(OVNRow)
 	row["name"] = lr
-	lruuid := odbi.getRowUUID(TableLogicalRouter, row)
+	lruuid, err := odbi.getRowUUID(TableLogicalRouter, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lruuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -215,7 +242,10 @@ func (odbi *ovndb) lrlbDelImp(lr string, lb string) (*OvnCommand, error) {
 	var operations []libovsdb.Operation
 	row := make(OVNRow)
 	row["name"] = lb
-	lbuuid := odbi.getRowUUID(TableLoadBalancer, row)
+	lbuuid, err := odbi.getRowUUID(TableLoadBalancer, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lbuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -226,7 +256,10 @@ func (odbi *ovndb) lrlbDelImp(lr string, lb string) (*OvnCommand, error) {
 	}
 	row = make(OVNRow)
 	row["name"] = lr
-	lruuid := odbi.getRowUUID(TableLogicalRouter, row)
+	lruuid, err := odbi.getRowUUID(TableLogicalRouter, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lruuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -244,6 +277,9 @@ func (odbi *ovndb) lrlbDelImp(lr string, lb string) (*OvnCommand, error) {
 }
 
 func (odbi *ovndb) lrlbListImp(lr string) ([]*LoadBalancer, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "load_balancer"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
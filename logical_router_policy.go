@@ -0,0 +1,251 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ebay/libovsdb"
+)
+
+// Actions of logical router policies
+const (
+	LRPolicyActionAllow   = "allow"
+	LRPolicyActionDrop    = "drop"
+	LRPolicyActionReroute = "reroute"
+)
+
+// LogicalRouterPolicy ovnnb item
+type LogicalRouterPolicy struct {
+	UUID       string
+	Priority   int
+	Match      string
+	Action     string
+	Nexthops   []string
+	Options    map[interface{}]interface{}
+	ExternalID map[interface{}]interface{}
+}
+
+// lrPolicyUUIDs returns the policies of lr
+func (odbi *ovndb) lrPolicyUUIDs(lr string) ([]string, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "policies"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for _, drows := range odbi.cache[TableLogicalRouter] {
+		if rlr, ok := drows.Fields["name"].(string); ok && rlr == lr {
+			switch policies := drows.Fields["policies"].(type) {
+			case libovsdb.UUID:
+				return []string{policies.GoUUID}, nil
+			case libovsdb.OvsSet:
+				return odbi.ConvertGoSetToStringArray(policies), nil
+			}
+			return nil, nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+func (odbi *ovndb) lrPolicyAddImp(lr string, priority int, match, action string, nexthops []string, external_ids map[string]string) (*OvnCommand, error) {
+	if priority < 0 || priority > 32767 {
+		return nil, errorf(ErrorOption, "priority %d out of range [0, 32767]", priority)
+	}
+	if len(match) == 0 {
+		return nil, fmt.Errorf("match cannot be empty")
+	}
+	switch action {
+	case LRPolicyActionAllow, LRPolicyActionDrop:
+		if len(nexthops) > 0 {
+			return nil, errorf(ErrorOption, "nexthops are only for action %s", LRPolicyActionReroute)
+		}
+	case LRPolicyActionReroute:
+		if len(nexthops) == 0 {
+			return nil, errorf(ErrorOption, "action %s needs a nexthop", action)
+		}
+	default:
+		return nil, errorf(ErrorOption, "unknown action %q", action)
+	}
+
+	policies, err := odbi.lrPolicyListImp(lr)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		if policy.Priority == priority && policy.Match == match {
+			return nil, ErrorExist
+		}
+	}
+
+	namedUUID, err := newRowUUID()
+	if err != nil {
+		return nil, err
+	}
+	row := make(OVNRow)
+	row["priority"] = priority
+	row["match"] = match
+	row["action"] = action
+	if len(nexthops) > 0 {
+		schema, _ := odbi.getSchema()
+		if _, ok := schema.Tables[TableLogicalRouterPolicy].Columns["nexthops"]; ok {
+			row["nexthops"], err = libovsdb.NewOvsSet(nexthops)
+			if err != nil {
+				return nil, err
+			}
+		} else if len(nexthops) == 1 {
+			// schemas before OVN 20.06 only have one nexthop
+			row["nexthop"] = nexthops[0]
+		} else {
+			return nil, errorf(ErrorOption, "database %s supports only one nexthop", odbi.db)
+		}
+	}
+	if external_ids != nil {
+		oMap, err := libovsdb.NewOvsMap(external_ids)
+		if err != nil {
+			return nil, err
+		}
+		row["external_ids"] = oMap
+	}
+	insertOp := libovsdb.Operation{
+		Op:       opInsert,
+		Table:    TableLogicalRouterPolicy,
+		Row:      row,
+		UUIDName: namedUUID,
+	}
+
+	mutateSet, err := libovsdb.NewOvsSet([]libovsdb.UUID{stringToGoUUID(namedUUID)})
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("policies", opInsert, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", lr)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalRouter,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{insertOp, mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) lrPolicyDelImp(lr string, priority int, match string) (*OvnCommand, error) {
+	policies, err := odbi.lrPolicyListImp(lr)
+	if err != nil {
+		return nil, err
+	}
+	var uuids []libovsdb.UUID
+	for _, policy := range policies {
+		if (priority == -1 || policy.Priority == priority) && (len(match) == 0 || policy.Match == match) {
+			uuids = append(uuids, stringToGoUUID(policy.UUID))
+		}
+	}
+	if len(uuids) == 0 {
+		return nil, ErrorNotFound
+	}
+	return odbi.lrPolicyRemove(lr, uuids)
+}
+
+func (odbi *ovndb) lrPolicyDelByUUIDImp(lr, uuid string) (*OvnCommand, error) {
+	uuids, err := odbi.lrPolicyUUIDs(lr)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range uuids {
+		if u == uuid {
+			return odbi.lrPolicyRemove(lr, []libovsdb.UUID{stringToGoUUID(uuid)})
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+// lrPolicyRemove removes policies from lr, they are garbage collected
+func (odbi *ovndb) lrPolicyRemove(lr string, uuids []libovsdb.UUID) (*OvnCommand, error) {
+	mutateSet, err := libovsdb.NewOvsSet(uuids)
+	if err != nil {
+		return nil, err
+	}
+	mutation := libovsdb.NewMutation("policies", opDelete, mutateSet)
+	condition := libovsdb.NewCondition("name", "==", lr)
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableLogicalRouter,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+// lrPolicyListImp returns the policies of lr by decreasing priority
+func (odbi *ovndb) lrPolicyListImp(lr string) ([]*LogicalRouterPolicy, error) {
+	uuids, err := odbi.lrPolicyUUIDs(lr)
+	if err != nil {
+		return nil, err
+	}
+	if err := odbi.requireColumns(TableLogicalRouterPolicy, "priority", "match"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	policies := make([]*LogicalRouterPolicy, 0, len(uuids))
+	for _, uuid := range uuids {
+		if _, ok := odbi.cache[TableLogicalRouterPolicy][uuid]; ok {
+			policies = append(policies, odbi.rowToLogicalRouterPolicy(uuid))
+		}
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		if policies[i].Priority != policies[j].Priority {
+			return policies[i].Priority > policies[j].Priority
+		}
+		return policies[i].Match < policies[j].Match
+	})
+	return policies, nil
+}
+
+func (odbi *ovndb) rowToLogicalRouterPolicy(uuid string) *LogicalRouterPolicy {
+	cacheLogicalRouterPolicy := odbi.cache[TableLogicalRouterPolicy][uuid]
+	priority, _ := cacheLogicalRouterPolicy.Fields["priority"].(int)
+	match, _ := cacheLogicalRouterPolicy.Fields["match"].(string)
+	action, _ := cacheLogicalRouterPolicy.Fields["action"].(string)
+	options, _ := cacheLogicalRouterPolicy.Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := cacheLogicalRouterPolicy.Fields["external_ids"].(libovsdb.OvsMap)
+	policy := &LogicalRouterPolicy{
+		UUID:       uuid,
+		Priority:   priority,
+		Match:      match,
+		Action:     action,
+		Options:    options.GoMap,
+		ExternalID: extIDs.GoMap,
+	}
+	// nexthop is deprecated, and only read from schemas without nexthops
+	column := "nexthops"
+	schema, _ := odbi.getSchema()
+	if _, ok := schema.Tables[TableLogicalRouterPolicy].Columns[column]; !ok {
+		column = "nexthop"
+	}
+	switch nexthops := cacheLogicalRouterPolicy.Fields[column].(type) {
+	case string:
+		policy.Nexthops = []string{nexthops}
+	case libovsdb.OvsSet:
+		policy.Nexthops = odbi.ConvertGoSetToStringArray(nexthops)
+	}
+	return policy
+}
@@ -0,0 +1,189 @@
+package goovn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	LRPOLICY_LR    = "lrpolicy_lr"
+	LRPOLICY_MATCH = "ip4.src == 10.0.0.0/24"
+)
+
+func TestLogicalRouterPolicy(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	cmd, err := ovndbapi.LRAdd(LRPOLICY_LR, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.LRDel(LRPOLICY_LR)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	policies := make(chan *LogicalRouterPolicy, 10)
+	sub := ovndbapi.Subscribe(TableLogicalRouterPolicy, func(event Event) {
+		if event.Type == EventCreate {
+			policies <- event.New.(*LogicalRouterPolicy)
+		}
+	})
+	defer sub.Unsubscribe()
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err = ovndbapi.LRPolicyAdd(LRPOLICY_LR, 100, LRPOLICY_MATCH, LRPolicyActionReroute,
+		[]string{"10.1.0.1", "10.1.0.2"}, map[string]string{"foo": "bar"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LRPolicyAdd(LRPOLICY_LR, 200, "ip4.dst == 10.2.0.0/16", LRPolicyActionAllow, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = ovndbapi.LRPolicyAdd(LRPOLICY_LR, 100, "ip4.dst == 10.3.0.0/16", LRPolicyActionDrop, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = ovndbapi.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Eventually(t, func() bool {
+		return len(policies) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	list, err := ovndbapi.LRPolicyList(LRPOLICY_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 3, len(list)) {
+		assert.Equal(t, 200, list[0].Priority)
+		assert.Equal(t, LRPolicyActionAllow, list[0].Action)
+		assert.Equal(t, LRPOLICY_MATCH, list[2].Match)
+		assert.Equal(t, LRPolicyActionReroute, list[2].Action)
+		assert.ElementsMatch(t, []string{"10.1.0.1", "10.1.0.2"}, list[2].Nexthops)
+		assert.Equal(t, "bar", list[2].ExternalID["foo"])
+	}
+	lrs, err := ovndbapi.LRGet(LRPOLICY_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(lrs[0].Policies))
+
+	_, err = ovndbapi.LRPolicyAdd(LRPOLICY_LR, 100, LRPOLICY_MATCH, LRPolicyActionDrop, nil, nil)
+	assert.Equal(t, ErrorExist, err)
+	_, err = ovndbapi.LRPolicyAdd(LRPOLICY_LR, 300, LRPOLICY_MATCH, LRPolicyActionReroute, nil, nil)
+	assert.True(t, isError(err, ErrorOption), err)
+	_, err = ovndbapi.LRPolicyAdd(LRPOLICY_LR, 300, LRPOLICY_MATCH, "forward", nil, nil)
+	assert.True(t, isError(err, ErrorOption), err)
+
+	cmd, err = ovndbapi.LRPolicyDelByUUID(LRPOLICY_LR, list[0].UUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = ovndbapi.LRPolicyDel(LRPOLICY_LR, 100, LRPOLICY_MATCH)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err = ovndbapi.LRPolicyList(LRPOLICY_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(list)) {
+		assert.Equal(t, LRPolicyActionDrop, list[0].Action)
+	}
+
+	cmd, err = ovndbapi.LRPolicyDel(LRPOLICY_LR, -1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err = ovndbapi.LRPolicyList(LRPOLICY_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(list))
+	_, err = ovndbapi.LRPolicyDel(LRPOLICY_LR, -1, "")
+	assert.Equal(t, ErrorNotFound, err)
+}
+
+func TestLogicalRouterPolicyNexthop(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	cmd, err := ovndbapi.LRAdd(LRPOLICY_LR, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.LRDel(LRPOLICY_LR)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// a policy written by an older client, which set the deprecated nexthop too
+	nexthops, err := libovsdb.NewOvsSet([]string{"10.1.0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	row := make(OVNRow)
+	row["priority"] = 100
+	row["match"] = LRPOLICY_MATCH
+	row["action"] = LRPolicyActionReroute
+	row["nexthop"] = "10.9.0.9"
+	row["nexthops"] = nexthops
+	policies, err := libovsdb.NewOvsSet([]libovsdb.UUID{{GoUUID: "policy"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opInsert, Table: TableLogicalRouterPolicy, Row: row, UUIDName: "policy"},
+		libovsdb.Operation{Op: opMutate, Table: TableLogicalRouter,
+			Mutations: []interface{}{libovsdb.NewMutation("policies", opInsert, policies)},
+			Where:     []interface{}{libovsdb.NewCondition("name", "==", LRPOLICY_LR)}})
+
+	list, err := ovndbapi.LRPolicyList(LRPOLICY_LR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(list)) {
+		assert.Equal(t, []string{"10.1.0.1"}, list[0].Nexthops)
+	}
+}
@@ -27,6 +27,7 @@ type LogicalRouterPort struct {
 	UUID           string
 	Name           string
 	GatewayChassis []string
+	HAChassisGroup string
 	Networks       []string
 	MAC            string
 	Enabled        bool
@@ -37,6 +38,9 @@ type LogicalRouterPort struct {
 }
 
 func (odbi *ovndb) lrpAddImp(lr string, lrp string, mac string, network []string, peer string, external_ids map[string]string) (*OvnCommand, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "ports"); err != nil {
+		return nil, err
+	}
 	namedUUID, err := newRowUUID()
 	if err != nil {
 		return nil, err
@@ -62,7 +66,9 @@ func (odbi *ovndb) lrpAddImp(lr string, lrp string, mac string, network []string
 		row["external_ids"] = oMap
 	}
 
-	if uuid := odbi.getRowUUID(TableLogicalRouterPort, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalRouterPort, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -96,7 +102,10 @@ func (odbi *ovndb) lrpDelImp(lr, lrp string) (*OvnCommand, error) {
 	row := make(OVNRow)
 	row["name"] = lrp
 
-	lrpUUID := odbi.getRowUUID(TableLogicalRouterPort, row)
+	lrpUUID, err := odbi.getRowUUID(TableLogicalRouterPort, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lrpUUID) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -131,11 +140,14 @@ func (odbi *ovndb) lrpDelImp(lr, lrp string) (*OvnCommand, error) {
 }
 
 func (odbi *ovndb) rowToLogicalRouterPort(uuid string) *LogicalRouterPort {
+	name, _ := odbi.cache[TableLogicalRouterPort][uuid].Fields["name"].(string)
+	mac, _ := odbi.cache[TableLogicalRouterPort][uuid].Fields["mac"].(string)
+	extIDs, _ := odbi.cache[TableLogicalRouterPort][uuid].Fields["external_ids"].(libovsdb.OvsMap)
 	lrp := &LogicalRouterPort{
 		UUID:       uuid,
-		Name:       odbi.cache[TableLogicalRouterPort][uuid].Fields["name"].(string),
-		MAC:        odbi.cache[TableLogicalRouterPort][uuid].Fields["mac"].(string),
-		ExternalID: odbi.cache[TableLogicalRouterPort][uuid].Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Name:       name,
+		MAC:        mac,
+		ExternalID: extIDs.GoMap,
 	}
 
 	if peer, ok := odbi.cache[TableLogicalRouterPort][uuid].Fields["peer"]; ok {
@@ -171,6 +183,9 @@ func (odbi *ovndb) rowToLogicalRouterPort(uuid string) *LogicalRouterPort {
 	case libovsdb.OvsSet:
 		lrp.GatewayChassis = odbi.ConvertGoSetToStringArray(gateway_chassis.(libovsdb.OvsSet))
 	}
+	if hg, ok := odbi.cache[TableLogicalRouterPort][uuid].Fields["ha_chassis_group"].(libovsdb.UUID); ok {
+		lrp.HAChassisGroup = hg.GoUUID
+	}
 	networks := odbi.cache[TableLogicalRouterPort][uuid].Fields["networks"]
 	switch networks.(type) {
 	case string:
@@ -183,6 +198,9 @@ func (odbi *ovndb) rowToLogicalRouterPort(uuid string) *LogicalRouterPort {
 }
 
 func (odbi *ovndb) lrpListImp(lr string) ([]*LogicalRouterPort, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "ports"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -33,6 +33,9 @@ type LogicalRouterStaticRoute struct {
 }
 
 func (odbi *ovndb) lrsrAddImp(lr string, ip_prefix string, nexthop string, output_port *string, policy *string, external_ids map[string]string) (*OvnCommand, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "static_routes"); err != nil {
+		return nil, err
+	}
 	namedUUID, err := newRowUUID()
 	if err != nil {
 		return nil, err
@@ -55,7 +58,9 @@ func (odbi *ovndb) lrsrAddImp(lr string, ip_prefix string, nexthop string, outpu
 		row["external_ids"] = oMap
 	}
 
-	if uuid := odbi.getRowUUID(TableLogicalRouterStaticRoute, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalRouterStaticRoute, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -104,7 +109,10 @@ func (odbi *ovndb) lrsrDelImp(lr string, prefix string, nexthop, outputPort, pol
 	if outputPort != nil {
 		row["output_port"] = *outputPort
 	}
-	lrsruuid := odbi.getRowUUID(TableLogicalRouterStaticRoute, row)
+	lrsruuid, err := odbi.getRowUUID(TableLogicalRouterStaticRoute, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lrsruuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -115,7 +123,10 @@ func (odbi *ovndb) lrsrDelImp(lr string, prefix string, nexthop, outputPort, pol
 	}
 	row = make(OVNRow)
 	row["name"] = lr
-	lruuid := odbi.getRowUUID(TableLogicalRouter, row)
+	lruuid, err := odbi.getRowUUID(TableLogicalRouter, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lruuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -141,7 +152,10 @@ func (odbi *ovndb) lrsrDelByUUIDImp(lr, uuid string) (*OvnCommand, error) {
 	}
 	row := make(OVNRow)
 	row["name"] = lr
-	lruuid := odbi.getRowUUID(TableLogicalRouter, row)
+	lruuid, err := odbi.getRowUUID(TableLogicalRouter, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lruuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -168,11 +182,14 @@ func (odbi *ovndb) rowToLogicalRouterStaticRoute(uuid string) *LogicalRouterStat
 	if !ok {
 		return nil
 	}
+	ipPrefix, _ := cacheLogicalRouterStaticRoute.Fields["ip_prefix"].(string)
+	nexthop, _ := cacheLogicalRouterStaticRoute.Fields["nexthop"].(string)
+	extIDs, _ := cacheLogicalRouterStaticRoute.Fields["external_ids"].(libovsdb.OvsMap)
 	lrsr := &LogicalRouterStaticRoute{
 		UUID:       uuid,
-		IPPrefix:   cacheLogicalRouterStaticRoute.Fields["ip_prefix"].(string),
-		Nexthop:    cacheLogicalRouterStaticRoute.Fields["nexthop"].(string),
-		ExternalID: cacheLogicalRouterStaticRoute.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		IPPrefix:   ipPrefix,
+		Nexthop:    nexthop,
+		ExternalID: extIDs.GoMap,
 	}
 
 	if policy, ok := cacheLogicalRouterStaticRoute.Fields["policy"]; ok {
@@ -185,6 +202,9 @@ func (odbi *ovndb) rowToLogicalRouterStaticRoute(uuid string) *LogicalRouterStat
 }
 
 func (odbi *ovndb) lrsrListImp(lr string) ([]*LogicalRouterStaticRoute, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "static_routes"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
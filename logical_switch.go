@@ -45,7 +45,9 @@ func (odbi *ovndb) lsAddImp(lsw string) (*OvnCommand, error) {
 	lswitch := make(OVNRow)
 	lswitch["name"] = lsw
 
-	if uuid := odbi.getRowUUID(TableLogicalSwitch, lswitch); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalSwitch, lswitch); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -76,11 +78,15 @@ func (odbi *ovndb) rowToLogicalSwitch(uuid string) *LogicalSwitch {
 		return nil
 	}
 
+	name, _ := cacheLogicalSwitch.Fields["name"].(string)
+	otherConfig, _ := cacheLogicalSwitch.Fields["other_config"].(libovsdb.OvsMap)
+	extIDs, _ := cacheLogicalSwitch.Fields["external_ids"].(libovsdb.OvsMap)
+
 	ls := &LogicalSwitch{
 		UUID:        uuid,
-		Name:        cacheLogicalSwitch.Fields["name"].(string),
-		OtherConfig: cacheLogicalSwitch.Fields["other_config"].(libovsdb.OvsMap).GoMap,
-		ExternalID:  cacheLogicalSwitch.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Name:        name,
+		OtherConfig: otherConfig.GoMap,
+		ExternalID:  extIDs.GoMap,
 	}
 	if ports, ok := cacheLogicalSwitch.Fields["ports"]; ok {
 		switch ports.(type) {
@@ -127,6 +133,9 @@ func (odbi *ovndb) rowToLogicalSwitch(uuid string) *LogicalSwitch {
 }
 
 func (odbi *ovndb) lsGetImp(ls string) ([]*LogicalSwitch, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name"); err != nil {
+		return nil, err
+	}
 	var lsList []*LogicalSwitch
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
@@ -149,6 +158,9 @@ func (odbi *ovndb) lsGetImp(ls string) ([]*LogicalSwitch, error) {
 }
 
 func (odbi *ovndb) lsListImp() ([]*LogicalSwitch, error) {
+	if err := odbi.requireTable(TableLogicalSwitch); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -169,7 +181,10 @@ func (odbi *ovndb) lslbAddImp(lswitch string, lb string) (*OvnCommand, error) {
 	var operations []libovsdb.Operation
 	row := make(OVNRow)
 	row["name"] = lb
-	lbuuid := odbi.getRowUUID(TableLoadBalancer, row)
+	lbuuid, err := odbi.getRowUUID(TableLoadBalancer, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lbuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -181,7 +196,10 @@ func (odbi *ovndb) lslbAddImp(lswitch string, lb string) (*OvnCommand, error) {
 	}
 	row = make(OVNRow)
 	row["name"] = lswitch
-	lsuuid := odbi.getRowUUID(TableLogicalSwitch, row)
+	lsuuid, err := odbi.getRowUUID(TableLogicalSwitch, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lsuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -200,13 +218,19 @@ func (odbi *ovndb) lslbDelImp(lswitch string, lb string) (*OvnCommand, error) {
 	var operations []libovsdb.Operation
 	row := make(OVNRow)
 	row["name"] = lb
-	lbuuid := odbi.getRowUUID(TableLoadBalancer, row)
+	lbuuid, err := odbi.getRowUUID(TableLoadBalancer, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lbuuid) == 0 {
 		return nil, ErrorNotFound
 	}
 	row = make(OVNRow)
 	row["name"] = lswitch
-	lsuuid := odbi.getRowUUID(TableLogicalSwitch, row)
+	lsuuid, err := odbi.getRowUUID(TableLogicalSwitch, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lsuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -229,6 +253,9 @@ func (odbi *ovndb) lslbDelImp(lswitch string, lb string) (*OvnCommand, error) {
 }
 
 func (odbi *ovndb) lslbListImp(lswitch string) ([]*LoadBalancer, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "load_balancer"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -281,7 +308,10 @@ func (odbi *ovndb) lsExtIdsAddImp(ls string, external_ids map[string]string) (*O
 	var operations []libovsdb.Operation
 	row := make(OVNRow)
 	row["name"] = ls
-	lsuuid := odbi.getRowUUID(TableLogicalSwitch, row)
+	lsuuid, err := odbi.getRowUUID(TableLogicalSwitch, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lsuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -308,7 +338,10 @@ func (odbi *ovndb) lsExtIdsDelImp(ls string, external_ids map[string]string) (*O
 	var operations []libovsdb.Operation
 	row := make(OVNRow)
 	row["name"] = ls
-	lsuuid := odbi.getRowUUID(TableLogicalSwitch, row)
+	lsuuid, err := odbi.getRowUUID(TableLogicalSwitch, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lsuuid) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -335,7 +368,10 @@ func (odbi *ovndb) linkSwitchToRouterImp(lsw, lsp, lr, lrp, lrpMac string, netwo
 	// validate logical switch
 	row := make(OVNRow)
 	row["name"] = lsw
-	lswUUID := odbi.getRowUUID(TableLogicalSwitch, row)
+	lswUUID, err := odbi.getRowUUID(TableLogicalSwitch, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lswUUID) == 0 {
 		return nil, fmt.Errorf("logical switch %s not found", lsw)
 	}
@@ -348,7 +384,9 @@ func (odbi *ovndb) linkSwitchToRouterImp(lsw, lsp, lr, lrp, lrpMac string, netwo
 	row["name"] = lrp
 	row["mac"] = lrpMac
 	// validate
-	if uuid := odbi.getRowUUID(TableLogicalRouterPort, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalRouterPort, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, fmt.Errorf("logical router port %s already existed", lrp)
 	}
 	networkSet, err := libovsdb.NewOvsSet(networks)
@@ -398,7 +436,9 @@ func (odbi *ovndb) linkSwitchToRouterImp(lsw, lsp, lr, lrp, lrpMac string, netwo
 	options["router-port"] = lrp
 	optMap, _ := libovsdb.NewOvsMap(options)
 	port["options"] = optMap
-	if uuid := odbi.getRowUUID(TableLogicalSwitchPort, port); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalSwitchPort, port); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 	addLspOp := libovsdb.Operation{
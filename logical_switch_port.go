@@ -34,10 +34,14 @@ type LogicalSwitchPort struct {
 	PortSecurity     []string
 	DHCPv4Options    string
 	DHCPv6Options    string
+	HAChassisGroup   string
 	ExternalID       map[interface{}]interface{}
 }
 
 func (odbi *ovndb) lspAddImp(lsw, lsp string) (*OvnCommand, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "ports"); err != nil {
+		return nil, err
+	}
 	namedUUID, err := newRowUUID()
 	if err != nil {
 		return nil, err
@@ -45,7 +49,9 @@ func (odbi *ovndb) lspAddImp(lsw, lsp string) (*OvnCommand, error) {
 	row := make(OVNRow)
 	row["name"] = lsp
 
-	if uuid := odbi.getRowUUID(TableLogicalSwitchPort, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableLogicalSwitchPort, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -79,7 +85,10 @@ func (odbi *ovndb) lspDelImp(lsp string) (*OvnCommand, error) {
 	row := make(OVNRow)
 	row["name"] = lsp
 
-	lspUUID := odbi.getRowUUID(TableLogicalSwitchPort, row)
+	lspUUID, err := odbi.getRowUUID(TableLogicalSwitchPort, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lspUUID) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -265,6 +274,23 @@ func (odbi *ovndb) lspGetDynamicAddressesImp(lsp string) (string, error) {
 	return lp.DynamicAddresses, nil
 }
 
+func (odbi *ovndb) lspSetTypeImp(lsp string, portType string) (*OvnCommand, error) {
+	if len(lsp) == 0 {
+		return nil, fmt.Errorf("LSP name cannot be empty while setting type")
+	}
+	row := make(OVNRow)
+	row["type"] = portType
+	condition := libovsdb.NewCondition("name", "==", lsp)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TableLogicalSwitchPort,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
 func (odbi *ovndb) lspSetExternalIdsImp(lsp string, external_ids map[string]string) (*OvnCommand, error) {
 	if external_ids == nil {
 		return nil, ErrorOption
@@ -313,11 +339,14 @@ func (odbi *ovndb) lspGetExternalIdsImp(lsp string) (map[string]string, error) {
 }
 
 func (odbi *ovndb) rowToLogicalPort(uuid string) (*LogicalSwitchPort, error) {
+	name, _ := odbi.cache[TableLogicalSwitchPort][uuid].Fields["name"].(string)
+	portType, _ := odbi.cache[TableLogicalSwitchPort][uuid].Fields["type"].(string)
+	extIDs, _ := odbi.cache[TableLogicalSwitchPort][uuid].Fields["external_ids"].(libovsdb.OvsMap)
 	lp := &LogicalSwitchPort{
 		UUID:       uuid,
-		Name:       odbi.cache[TableLogicalSwitchPort][uuid].Fields["name"].(string),
-		Type:       odbi.cache[TableLogicalSwitchPort][uuid].Fields["type"].(string),
-		ExternalID: odbi.cache[TableLogicalSwitchPort][uuid].Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Name:       name,
+		Type:       portType,
+		ExternalID: extIDs.GoMap,
 	}
 
 	if dhcpv4, ok := odbi.cache[TableLogicalSwitchPort][uuid].Fields["dhcpv4_options"]; ok {
@@ -337,6 +366,10 @@ func (odbi *ovndb) rowToLogicalPort(uuid string) (*LogicalSwitchPort, error) {
 		}
 	}
 
+	if hg, ok := odbi.cache[TableLogicalSwitchPort][uuid].Fields["ha_chassis_group"].(libovsdb.UUID); ok {
+		lp.HAChassisGroup = hg.GoUUID
+	}
+
 	if addr, ok := odbi.cache[TableLogicalSwitchPort][uuid].Fields["addresses"]; ok {
 		switch addr.(type) {
 		case string:
@@ -379,6 +412,9 @@ func (odbi *ovndb) rowToLogicalPort(uuid string) (*LogicalSwitchPort, error) {
 
 // Get lsp by name
 func (odbi *ovndb) lspGetImp(lsp string) (*LogicalSwitchPort, error) {
+	if err := odbi.requireColumns(TableLogicalSwitchPort, "name"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -397,6 +433,9 @@ func (odbi *ovndb) lspGetImp(lsp string) (*LogicalSwitchPort, error) {
 
 // Get all lport by lswitch
 func (odbi *ovndb) lspListImp(lsw string) ([]*LogicalSwitchPort, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "ports"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -0,0 +1,170 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"time"
+
+	"github.com/ebay/libovsdb"
+)
+
+// MACBinding ovnsb item, Timestamp is zero if ovn-controller does not set it
+type MACBinding struct {
+	UUID        string
+	LogicalPort string
+	IP          string
+	MAC         string
+	Datapath    string
+	Timestamp   time.Time
+}
+
+// macBindingListFunc returns the MAC bindings f is true for, f is called without
+// the cache lock held so that it may call the client
+func (odbi *ovndb) macBindingListFunc(f func(*MACBinding) bool) ([]*MACBinding, error) {
+	if err := odbi.requireTable(TableMACBinding); err != nil {
+		return nil, err
+	}
+	mbs := func() []*MACBinding {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+
+		// a table without rows has no entry in the cache
+		mbs := make([]*MACBinding, 0, len(odbi.cache[TableMACBinding]))
+		for uuid := range odbi.cache[TableMACBinding] {
+			mbs = append(mbs, odbi.rowToMACBinding(uuid))
+		}
+		return mbs
+	}()
+
+	var listMACBinding []*MACBinding
+	for _, mb := range mbs {
+		if f(mb) {
+			listMACBinding = append(listMACBinding, mb)
+		}
+	}
+	return listMACBinding, nil
+}
+
+func (odbi *ovndb) macBindingListImp() ([]*MACBinding, error) {
+	return odbi.macBindingListFunc(func(*MACBinding) bool { return true })
+}
+
+func (odbi *ovndb) macBindingListByPortImp(lport string) ([]*MACBinding, error) {
+	if err := odbi.requireColumns(TableMACBinding, "logical_port"); err != nil {
+		return nil, err
+	}
+	return odbi.macBindingListFunc(func(mb *MACBinding) bool { return mb.LogicalPort == lport })
+}
+
+func (odbi *ovndb) macBindingListByDatapathImp(datapath string) ([]*MACBinding, error) {
+	if err := odbi.requireColumns(TableMACBinding, "datapath"); err != nil {
+		return nil, err
+	}
+	return odbi.macBindingListFunc(func(mb *MACBinding) bool { return mb.Datapath == datapath })
+}
+
+func (odbi *ovndb) macBindingListByIPImp(ip string) ([]*MACBinding, error) {
+	if err := odbi.requireColumns(TableMACBinding, "ip"); err != nil {
+		return nil, err
+	}
+	return odbi.macBindingListFunc(func(mb *MACBinding) bool { return mb.IP == ip })
+}
+
+func (odbi *ovndb) macBindingDelImp(uuid string) (*OvnCommand, error) {
+	if err := odbi.requireTable(TableMACBinding); err != nil {
+		return nil, err
+	}
+	found := func() bool {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+		_, ok := odbi.cache[TableMACBinding][uuid]
+		return ok
+	}()
+	if !found {
+		return nil, ErrorNotFound
+	}
+	return odbi.macBindingDelete([]*MACBinding{{UUID: uuid}}), nil
+}
+
+// macBindingDelFuncImp deletes the MAC bindings f is true for, ErrorNoChanges if there is none
+func (odbi *ovndb) macBindingDelFuncImp(f func(*MACBinding) bool) (*OvnCommand, error) {
+	listMACBinding, err := odbi.macBindingListFunc(f)
+	if err != nil {
+		return nil, err
+	}
+	if len(listMACBinding) == 0 {
+		return nil, ErrorNoChanges
+	}
+	return odbi.macBindingDelete(listMACBinding), nil
+}
+
+// macBindingDelOlderThanImp deletes the MAC bindings last refreshed more than age ago,
+// the ones without timestamp are kept
+func (odbi *ovndb) macBindingDelOlderThanImp(age time.Duration) (*OvnCommand, error) {
+	schema, _ := odbi.getSchema()
+	if _, ok := schema.Tables[TableMACBinding].Columns["timestamp"]; !ok {
+		return nil, errorf(ErrorOption, "database %s has no MAC binding timestamp", odbi.db)
+	}
+	if err := odbi.requireColumns(TableMACBinding, "timestamp"); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(-age)
+	return odbi.macBindingDelFuncImp(func(mb *MACBinding) bool {
+		return !mb.Timestamp.IsZero() && mb.Timestamp.Before(deadline)
+	})
+}
+
+func (odbi *ovndb) macBindingDelete(listMACBinding []*MACBinding) *OvnCommand {
+	operations := make([]libovsdb.Operation, 0, len(listMACBinding))
+	for _, mb := range listMACBinding {
+		condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(mb.UUID))
+		deleteOp := libovsdb.Operation{
+			Op:    opDelete,
+			Table: TableMACBinding,
+			Where: []interface{}{condition},
+		}
+		operations = append(operations, deleteOp)
+	}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}
+}
+
+func (odbi *ovndb) rowToMACBinding(uuid string) *MACBinding {
+	cacheMACBinding, ok := odbi.cache[TableMACBinding][uuid]
+	if !ok {
+		return nil
+	}
+
+	lport, _ := cacheMACBinding.Fields["logical_port"].(string)
+	ip, _ := cacheMACBinding.Fields["ip"].(string)
+	mac, _ := cacheMACBinding.Fields["mac"].(string)
+	// milliseconds since the epoch
+	timestamp, _ := cacheMACBinding.Fields["timestamp"].(int)
+
+	mb := &MACBinding{
+		UUID:        uuid,
+		LogicalPort: lport,
+		IP:          ip,
+		MAC:         mac,
+	}
+	if datapath, ok := cacheMACBinding.Fields["datapath"].(libovsdb.UUID); ok {
+		mb.Datapath = datapath.GoUUID
+	}
+	if timestamp > 0 {
+		mb.Timestamp = time.Unix(0, int64(timestamp)*int64(time.Millisecond))
+	}
+	return mb
+}
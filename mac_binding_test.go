@@ -0,0 +1,166 @@
+package goovn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMACBinding(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	// no binding is not an error
+	mbs, err := ovndbapi.MACBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(mbs))
+	_, err = ovndbapi.MACBindingDelOlderThan(time.Hour)
+	assert.True(t, isError(err, ErrorNoChanges), err)
+
+	datapath := addDatapath(t, ovndbapi)
+	defer sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opDelete, Table: TableMACBinding, Where: []interface{}{}},
+		libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding,
+			Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", DP_KEY)}})
+
+	now := time.Now()
+	bindings := []struct {
+		lport     string
+		ip        string
+		mac       string
+		timestamp time.Time
+	}{
+		{"lrp-ext", "172.16.0.10", "00:00:00:00:01:01", now.Add(-2 * time.Hour)},
+		{"lrp-ext", "172.16.0.11", "00:00:00:00:01:02", now},
+		{"lrp-int", "172.16.0.10", "00:00:00:00:01:03", time.Time{}},
+	}
+	var operations []libovsdb.Operation
+	for _, mb := range bindings {
+		row := make(OVNRow)
+		row["logical_port"] = mb.lport
+		row["ip"] = mb.ip
+		row["mac"] = mb.mac
+		row["datapath"] = stringToGoUUID(datapath)
+		if !mb.timestamp.IsZero() {
+			row["timestamp"] = mb.timestamp.UnixNano() / int64(time.Millisecond)
+		}
+		operations = append(operations, libovsdb.Operation{Op: opInsert, Table: TableMACBinding, Row: row})
+	}
+	sbTransact(t, ovndbapi, operations...)
+
+	mbs, err = ovndbapi.MACBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(mbs))
+	mbs, err = ovndbapi.MACBindingListByPort("lrp-ext")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(mbs))
+	mbs, err = ovndbapi.MACBindingListByDatapath(datapath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, len(mbs))
+	mbs, err = ovndbapi.MACBindingListByIP("172.16.0.11")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(mbs)) {
+		assert.Equal(t, "00:00:00:00:01:02", mbs[0].MAC)
+		assert.Equal(t, now.UnixNano()/int64(time.Millisecond), mbs[0].Timestamp.UnixNano()/int64(time.Millisecond))
+	}
+
+	// only the binding refreshed 2 hours ago is stale
+	cmd, err := ovndbapi.MACBindingDelOlderThan(time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbs, err = ovndbapi.MACBindingListByIP("172.16.0.10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(mbs)) {
+		assert.Equal(t, "lrp-int", mbs[0].LogicalPort)
+		assert.True(t, mbs[0].Timestamp.IsZero())
+	}
+	_, err = ovndbapi.MACBindingDelOlderThan(time.Hour)
+	assert.True(t, isError(err, ErrorNoChanges), err)
+
+	cmd, err = ovndbapi.MACBindingDelFunc(func(mb *MACBinding) bool {
+		return mb.LogicalPort == "lrp-int"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbs, err = ovndbapi.MACBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(mbs)) {
+		cmd, err = ovndbapi.MACBindingDel(mbs[0].UUID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = ovndbapi.MACBindingDel(mbs[0].UUID)
+		assert.True(t, isError(err, ErrorNotFound), err)
+	}
+	mbs, err = ovndbapi.MACBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(mbs))
+}
+
+func TestMACBindingDelFuncStale(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	datapath := addDatapath(t, ovndbapi)
+	defer sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opDelete, Table: TableMACBinding, Where: []interface{}{}},
+		libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding,
+			Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", DP_KEY)}})
+
+	row := make(OVNRow)
+	row["logical_port"] = "lrp-gone"
+	row["ip"] = "172.16.0.20"
+	row["mac"] = "00:00:00:00:01:04"
+	row["datapath"] = stringToGoUUID(datapath)
+	sbTransact(t, ovndbapi, libovsdb.Operation{Op: opInsert, Table: TableMACBinding, Row: row})
+
+	// the predicate looks the port up in the cache of the client it is called by
+	cmd, err := ovndbapi.MACBindingDelFunc(func(mb *MACBinding) bool {
+		_, err := ovndbapi.PortBindingGet(mb.LogicalPort)
+		return isError(err, ErrorNotFound)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbs, err := ovndbapi.MACBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(mbs))
+}
@@ -0,0 +1,54 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ovsdbtest "github.com/ebay/go-ovn/testing"
+)
+
+// TestMain runs the tests against an in-memory OVSDB server, unless $OVN_NB_DB or
+// $OVS_RUNDIR point them at a running ovsdb-server
+func TestMain(m *testing.M) {
+	if os.Getenv("OVN_NB_DB") != "" || os.Getenv("OVS_RUNDIR") != "" {
+		os.Exit(m.Run())
+	}
+	dir, err := ioutil.TempDir("", "go-ovn")
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv, err := ovsdbtest.NewServer(ovsdbtest.NBSchema, ovsdbtest.SBSchema)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, socket := range []string{OVNNB_SOCKET, OVNSB_SOCKET} {
+		if _, err := srv.Listen("unix", filepath.Join(dir, socket)); err != nil {
+			log.Fatal(err)
+		}
+	}
+	os.Setenv("OVS_RUNDIR", dir)
+
+	code := m.Run()
+	srv.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
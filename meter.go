@@ -28,12 +28,17 @@ func (odbi *ovndb) rowToMeter(uuid string) *Meter {
 	if !ok {
 		return nil
 	}
+	name, _ := cacheMeter.Fields["name"].(string)
+	unit, _ := cacheMeter.Fields["unit"].(string)
+	extIDs, _ := cacheMeter.Fields["external_ids"].(libovsdb.OvsMap)
 	meter := &Meter{
 		UUID:        uuid,
-		Name:        cacheMeter.Fields["name"].(string),
-		Unit:        cacheMeter.Fields["unit"].(string),
-		Bands:       []string{cacheMeter.Fields["bands"].(libovsdb.UUID).GoUUID},
-		ExternalIds: cacheMeter.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Name:        name,
+		Unit:        unit,
+		ExternalIds: extIDs.GoMap,
+	}
+	if bands, ok := cacheMeter.Fields["bands"].(libovsdb.UUID); ok {
+		meter.Bands = []string{bands.GoUUID}
 	}
 	return meter
 }
@@ -43,12 +48,16 @@ func (odbi *ovndb) rowToMeterBand(uuid string) (*MeterBand, error) {
 	if !ok {
 		return nil, ErrorNotFound
 	}
+	action, _ := cacheMeterBand.Fields["action"].(string)
+	rate, _ := cacheMeterBand.Fields["rate"].(int)
+	burstSize, _ := cacheMeterBand.Fields["burst_size"].(int)
+	extIDs, _ := cacheMeterBand.Fields["external_ids"].(libovsdb.OvsMap)
 	meterBand := &MeterBand{
 		UUID:        uuid,
-		Action:      cacheMeterBand.Fields["action"].(string),
-		Rate:        cacheMeterBand.Fields["rate"].(int),
-		BurstSize:   cacheMeterBand.Fields["burst_size"].(int),
-		ExternalIds: cacheMeterBand.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Action:      action,
+		Rate:        rate,
+		BurstSize:   burstSize,
+		ExternalIds: extIDs.GoMap,
 	}
 	return meterBand, nil
 }
@@ -78,7 +87,9 @@ func (odbi *ovndb) meterAddImp(name, action string, rate int, unit string, exter
 	mRow := make(OVNRow)
 
 	mRow["name"] = name
-	if uuid := odbi.getRowUUID(TableMeter, mRow); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableMeter, mRow); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -144,6 +155,9 @@ func (odbi *ovndb) meterDelImp(name ...string) (*OvnCommand, error) {
 
 	switch len(name) {
 	case 0:
+		if err := odbi.requireColumns(TableMeter, "name"); err != nil {
+			return nil, err
+		}
 		for uuid := range odbi.cache[TableMeter] {
 			name := odbi.cache[TableMeter][uuid].Fields["name"].(string)
 			operations, err = odbi.singleMeterDel(name, operations)
@@ -167,6 +181,9 @@ func (odbi *ovndb) meterDelImp(name ...string) (*OvnCommand, error) {
 //Lists all meters.
 //but not like ovn-nbctl , it can't show meter bands information
 func (odbi *ovndb) meterListImp() ([]*Meter, error) {
+	if err := odbi.requireTable(TableMeter); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 	cacheMeter, ok := odbi.cache[TableMeter]
@@ -182,6 +199,9 @@ func (odbi *ovndb) meterListImp() ([]*Meter, error) {
 
 //Because meterList can't show meter bands , add this method as a solution
 func (odbi *ovndb) meterBandsListImp() ([]*MeterBand, error) {
+	if err := odbi.requireTable(TableMeterBand); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 	cacheMeterBands, ok := odbi.cache[TableMeterBand]
@@ -199,26 +219,35 @@ func (odbi *ovndb) meterBandsListImp() ([]*MeterBand, error) {
 	return ListMeterBands, nil
 }
 
-func (odbi *ovndb) meterFind(name string) bool {
+func (odbi *ovndb) meterFind(name string) (bool, error) {
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 	row := make(OVNRow)
 	row["name"] = name
-	meterUUID := odbi.getRowUUID(TableMeter, row)
+	meterUUID, err := odbi.getRowUUID(TableMeter, row)
+	if err != nil {
+		return false, err
+	}
 	if len(meterUUID) == 0 {
-		return false
+		return false, nil
 	}
-	return true
+	return true, nil
 }
 
 func (odbi *ovndb) singleMeterDel(name string, operations []libovsdb.Operation) ([]libovsdb.Operation, error) {
 	meterName := name
 	row := make(OVNRow)
 	row["name"] = meterName
-	meterUUID := odbi.getRowUUID(TableMeter, row)
+	meterUUID, err := odbi.getRowUUID(TableMeter, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(meterUUID) == 0 {
 		return nil, ErrorNotFound
 	}
+	if err := odbi.requireColumns(TableMeter, "bands"); err != nil {
+		return nil, err
+	}
 	bands := odbi.cache[TableMeter][meterUUID].Fields["bands"].(libovsdb.UUID)
 	mCondition := libovsdb.NewCondition("name", "==", meterName)
 	mDeleteOp := libovsdb.Operation{
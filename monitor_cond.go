@@ -0,0 +1,272 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ebay/libovsdb"
+)
+
+// monitorCondRequest is a <monitor-cond-request>, see ovsdb-server(7) monitor_cond
+type monitorCondRequest struct {
+	Columns []string                `json:"columns,omitempty"`
+	Where   []interface{}           `json:"where,omitempty"`
+	Select  *libovsdb.MonitorSelect `json:"select,omitempty"`
+}
+
+// tableUpdates2 is a <table-updates2>, mapping tables to the updates of their rows by UUID
+type tableUpdates2 map[string]map[string]rowUpdate2
+
+// rowUpdate2 is a <row-update2>, only one of its members is set. Initial and Insert hold
+// the columns that do not have their default value, Modify the difference of the columns
+// that changed.
+type rowUpdate2 struct {
+	Initial *libovsdb.Row
+	Insert  *libovsdb.Row
+	Delete  bool
+	Modify  *libovsdb.Row
+}
+
+func (r *rowUpdate2) UnmarshalJSON(b []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	for member, value := range raw {
+		var row *libovsdb.Row
+		switch member {
+		case "initial":
+			row = &libovsdb.Row{}
+			r.Initial = row
+		case "insert":
+			row = &libovsdb.Row{}
+			r.Insert = row
+		case "modify":
+			row = &libovsdb.Row{}
+			r.Modify = row
+		case "delete":
+			r.Delete = true
+			continue
+		default:
+			return fmt.Errorf("unknown row update member %q", member)
+		}
+		if err := json.Unmarshal(value, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update2Notifier is implemented by notifiers that handle the update2 notifications of monitor_cond
+type update2Notifier interface {
+	update2(context interface{}, tableUpdates tableUpdates2)
+}
+
+// update3Notifier is implemented by notifiers that handle the update3 notifications of
+// monitor_cond_since, which carry the id of the transaction they are the changes of
+type update3Notifier interface {
+	update3(context interface{}, txnID string, tableUpdates tableUpdates2)
+}
+
+// zeroTxnID is sent as the last transaction id when there is none, no changes are known since it
+const zeroTxnID = "00000000-0000-0000-0000-000000000000"
+
+// lastTxn returns the id of the last transaction applied to the cache
+func (odbi *ovndb) lastTxn() string {
+	odbi.connmutex.Lock()
+	defer odbi.connmutex.Unlock()
+	if len(odbi.lastTxnID) == 0 {
+		return zeroTxnID
+	}
+	return odbi.lastTxnID
+}
+
+func (odbi *ovndb) setLastTxn(txnID string) {
+	odbi.connmutex.Lock()
+	defer odbi.connmutex.Unlock()
+	odbi.lastTxnID = txnID
+}
+
+// monitorCondRequests returns the monitor_cond requests of the monitored tables,
+// with the rows selected by odbi.tableConds
+func (odbi *ovndb) monitorCondRequests() (map[string]monitorCondRequest, error) {
+	for table := range odbi.tableConds {
+		if _, ok := odbi.tableCols[table]; !ok {
+			return nil, fmt.Errorf("conditions specified for table %q that is not monitored", table)
+		}
+	}
+	requests := make(map[string]monitorCondRequest)
+	for table, columns := range odbi.tableCols {
+		requests[table] = monitorCondRequest{
+			Columns: columns,
+			Where:   odbi.tableConds[table],
+			Select: &libovsdb.MonitorSelect{
+				Initial: true,
+				Insert:  true,
+				Delete:  true,
+				Modify:  true,
+			}}
+	}
+	return requests, nil
+}
+
+func (odbi *ovndb) setTableCondsImp(ctx context.Context, table string, conds []interface{}) error {
+	if odbi.txn != nil {
+		// the conditions belong to the client, not to the transaction
+		return ErrorOption
+	}
+	if _, ok := odbi.tableCols[table]; !ok {
+		return fmt.Errorf("table %q is not monitored", table)
+	}
+	odbi.condmutex.Lock()
+	defer odbi.condmutex.Unlock()
+	if !odbi.condMonitor {
+		return fmt.Errorf("monitor_cond is not supported by %s", odbi.addr)
+	}
+	where := conds
+	if len(where) == 0 {
+		// an empty list would leave the conditions unchanged
+		where = []interface{}{true}
+	}
+	client, err := odbi.getClient()
+	if err != nil {
+		return err
+	}
+	requests := map[string]monitorCondRequest{table: {Where: where}}
+	// the monitor set up by connect, whose updates are processed before the reply
+	if err := client.monitorCondChange(ctx, "", "", requests); err != nil {
+		return err
+	}
+	if len(conds) == 0 {
+		delete(odbi.tableConds, table)
+	} else {
+		odbi.tableConds[table] = conds
+	}
+	return nil
+}
+
+// fromTableUpdates2 converts updates to the table-updates the cache is populated with,
+// completing the rows with the default values left out and applying modifications
+// to the cached rows
+func (odbi *ovndb) fromTableUpdates2(updates tableUpdates2) libovsdb.TableUpdates {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	schema, _ := odbi.client.getSchemaCached(odbi.db)
+	tableUpdates := libovsdb.TableUpdates{Updates: make(map[string]libovsdb.TableUpdate)}
+	for table, rows := range updates {
+		tableUpdate := libovsdb.TableUpdate{Rows: make(map[string]libovsdb.RowUpdate)}
+		columns := schema.Tables[table].Columns
+		for uuid, update := range rows {
+			old, cached := odbi.cache[table][uuid]
+			switch {
+			case update.Initial != nil || update.Insert != nil:
+				row := update.Initial
+				if row == nil {
+					row = update.Insert
+				}
+				fields := make(map[string]interface{}, len(columns))
+				for column, columnSchema := range columns {
+					if odbi.isMonitored(table, column) {
+						fields[column] = defaultDatum(columnSchema.Type)
+					}
+				}
+				for column, value := range row.Fields {
+					fields[column] = value
+				}
+				tableUpdate.Rows[uuid] = libovsdb.RowUpdate{Old: old, New: libovsdb.Row{Fields: fields}}
+			case update.Delete:
+				tableUpdate.Rows[uuid] = libovsdb.RowUpdate{Old: old}
+			case update.Modify != nil && cached:
+				fields := make(map[string]interface{}, len(old.Fields))
+				for column, value := range old.Fields {
+					fields[column] = value
+				}
+				for column, diff := range update.Modify.Fields {
+					fields[column] = applyDiff(columns[column].Type, fields[column], diff)
+				}
+				tableUpdate.Rows[uuid] = libovsdb.RowUpdate{Old: old, New: libovsdb.Row{Fields: fields}}
+			}
+		}
+		tableUpdates.Updates[table] = tableUpdate
+	}
+	return tableUpdates
+}
+
+// applyDiff returns the value of a column of the given schema type modified by diff,
+// see <row-update2> in ovsdb-server(7)
+func applyDiff(columnType interface{}, value, diff interface{}) interface{} {
+	if diffMap, ok := diff.(libovsdb.OvsMap); ok {
+		m := libovsdb.OvsMap{GoMap: make(map[interface{}]interface{})}
+		if valueMap, ok := value.(libovsdb.OvsMap); ok {
+			for k, v := range valueMap.GoMap {
+				m.GoMap[k] = v
+			}
+		}
+		for k, v := range diffMap.GoMap {
+			// pairs that are in the map are removed, new keys and values are set
+			if current, ok := m.GoMap[k]; ok && reflect.DeepEqual(current, v) {
+				delete(m.GoMap, k)
+			} else {
+				m.GoMap[k] = v
+			}
+		}
+		return m
+	}
+	if !isSetType(columnType) {
+		return diff
+	}
+	// the elements of the diff are added if absent and removed if present
+	current, elements := datumToAtoms(value), datumToAtoms(diff)
+	var atoms []interface{}
+	for _, atom := range current {
+		if !containsAtom(normalizeAtoms(elements), normalizeAtom(atom)) {
+			atoms = append(atoms, atom)
+		}
+	}
+	for _, atom := range elements {
+		if !containsAtom(normalizeAtoms(current), normalizeAtom(atom)) {
+			atoms = append(atoms, atom)
+		}
+	}
+	if len(atoms) == 0 {
+		return libovsdb.OvsSet{GoSet: []interface{}{}}
+	}
+	return atomsToDatum(atoms)
+}
+
+// normalizeAtom converts integral numbers to int, the form the cache holds them in
+func normalizeAtom(atom interface{}) interface{} {
+	if v, ok := atom.(float64); ok {
+		if n := int(v); float64(n) == v {
+			return n
+		}
+	}
+	return atom
+}
+
+func normalizeAtoms(atoms []interface{}) []interface{} {
+	normalized := make([]interface{}, len(atoms))
+	for i, atom := range atoms {
+		normalized[i] = normalizeAtom(atom)
+	}
+	return normalized
+}
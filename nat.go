@@ -37,12 +37,17 @@ func (odbi *ovndb) rowToNat(uuid string) *NAT {
 		return nil
 	}
 
+	natType, _ := cacheNAT.Fields["type"].(string)
+	externalIP, _ := cacheNAT.Fields["external_ip"].(string)
+	logicalIP, _ := cacheNAT.Fields["logical_ip"].(string)
+	extIDs, _ := cacheNAT.Fields["external_ids"].(libovsdb.OvsMap)
+
 	nat := &NAT{
 		UUID:       uuid,
-		Type:       cacheNAT.Fields["type"].(string),
-		ExternalIP: cacheNAT.Fields["external_ip"].(string),
-		LogicalIP:  cacheNAT.Fields["logical_ip"].(string),
-		ExternalID: cacheNAT.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Type:       natType,
+		ExternalIP: externalIP,
+		LogicalIP:  logicalIP,
+		ExternalID: extIDs.GoMap,
 	}
 
 	if mac, ok := cacheNAT.Fields["external_mac"]; ok {
@@ -68,6 +73,9 @@ func (odbi *ovndb) rowToNat(uuid string) *NAT {
 }
 
 func (odbi *ovndb) lrNatAddImp(lr string, ntype string, externalIp string, logicalIp string, external_ids map[string]string, logicalPortAndExternalMac ...string) (*OvnCommand, error) {
+	if err := odbi.requireColumns(TableLogicalRouter, "name", "nat"); err != nil {
+		return nil, err
+	}
 	nameUUID, err := newRowUUID()
 	if err != nil {
 		return nil, err
@@ -89,7 +97,9 @@ func (odbi *ovndb) lrNatAddImp(lr string, ntype string, externalIp string, logic
 		return nil, ErrorOption
 	}
 
-	if uuid := odbi.getRowUUID(TableNAT, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TableNAT, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -174,7 +184,10 @@ func (odbi *ovndb) lrNatDelImp(lr string, ntype string, ip ...string) (*OvnComma
 		return nil, ErrorOption
 	}
 
-	lrNatUUID := odbi.getRowUUIDs(TableNAT, row)
+	lrNatUUID, err := odbi.getRowUUIDs(TableNAT, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lrNatUUID) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -208,7 +221,10 @@ func (odbi *ovndb) lrNatDelImp(lr string, ntype string, ip ...string) (*OvnComma
 		return nil, err
 	}
 
-	lrNatUUID = odbi.getRowUUIDs(TableNAT, row)
+	lrNatUUID, err = odbi.getRowUUIDs(TableNAT, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(lrNatUUID) == 0 {
 		return nil, ErrorNotFound
 	}
@@ -0,0 +1,127 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+
+	"github.com/ebay/libovsdb"
+)
+
+// WaitFor tells ExecuteWait how far the change of its transaction must have gone
+type WaitFor int
+
+const (
+	// WaitNone returns once the transaction is committed, like Execute
+	WaitNone WaitFor = iota
+	// WaitSB waits for ovn-northd to apply the change to the southbound database, like ovn-nbctl --wait=sb
+	WaitSB
+	// WaitHV waits for all chassis to apply the change, like ovn-nbctl --wait=hv
+	WaitHV
+)
+
+// executeWaitImp executes cmds along with an increment of NB_Global nb_cfg, then
+// waits for sb_cfg or hv_cfg to reach it in the cache
+func (odbi *ovndb) executeWaitImp(ctx context.Context, wait WaitFor, cmds ...*OvnCommand) error {
+	switch wait {
+	case WaitNone:
+		return odbi.executeContext(ctx, cmds...)
+	case WaitSB, WaitHV:
+	default:
+		return errorf(ErrorOption, "unknown wait %d", wait)
+	}
+	if odbi.db != DBNB {
+		return errorf(ErrorOption, "database %s has no nb_cfg to wait for", odbi.db)
+	}
+	if odbi.txn != nil {
+		return errorf(ErrorOption, "cannot wait for a staged command")
+	}
+	column := "sb_cfg"
+	if wait == WaitHV {
+		column = "hv_cfg"
+	}
+	if err := odbi.requireColumns(TableNBGlobal, "nb_cfg", column); err != nil {
+		return err
+	}
+	uuid, _, err := odbi.globalRow()
+	if err != nil {
+		return err
+	}
+
+	// the updates of NB_Global may come before Execute returns
+	reached := make(chan struct{}, 1)
+	var target int
+	check := func() {
+		odbi.cachemutex.RLock()
+		defer odbi.cachemutex.RUnlock()
+		for _, drows := range odbi.cache[TableNBGlobal] {
+			if cfg, ok := drows.Fields[column].(int); ok && cfg >= target {
+				select {
+				case reached <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}
+	targetSet := make(chan struct{})
+	sub := odbi.subscribeImp(TableNBGlobal, func(Event) {
+		select {
+		case <-targetSet:
+			check()
+		default:
+		}
+	}, QueueConfig{}, nil)
+	defer sub.Unsubscribe()
+
+	mutation := libovsdb.NewMutation("nb_cfg", "+=", 1)
+	condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(uuid))
+	mutateOp := libovsdb.Operation{
+		Op:        opMutate,
+		Table:     TableNBGlobal,
+		Mutations: []interface{}{mutation},
+		Where:     []interface{}{condition},
+	}
+	selectOp := libovsdb.Operation{
+		Op:      opSelect,
+		Table:   TableNBGlobal,
+		Columns: []string{"nb_cfg"},
+		Where:   []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{mutateOp, selectOp}
+	bump := &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}
+	if err := odbi.executeContext(ctx, append(cmds, bump)...); err != nil {
+		return err
+	}
+	if len(bump.Results[1]) == 0 {
+		return errorf(ErrorNotFound, "no row in %s table", TableNBGlobal)
+	}
+	switch nbCfg := bump.Results[1][0]["nb_cfg"].(type) {
+	case float64:
+		target = int(nbCfg)
+	case int:
+		target = nbCfg
+	}
+	close(targetSet)
+	check()
+
+	select {
+	case <-reached:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,81 @@
+package goovn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecuteWait(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	// stands for ovn-northd, which reports nb_cfg in sb_cfg but no chassis reports hv_cfg
+	northd := getOVNClient(DBNB)
+	defer northd.Close()
+	sub := northd.SubscribeQueue(TableNBGlobal, func(event Event) {
+		_, global := event.NBGlobal()
+		if global == nil || global.SbCfg == global.NbCfg {
+			return
+		}
+		condition := libovsdb.NewCondition("_uuid", "==", stringToGoUUID(global.UUID))
+		operations := []libovsdb.Operation{{Op: opUpdate, Table: TableNBGlobal, Row: OVNRow{"sb_cfg": global.NbCfg}, Where: []interface{}{condition}}}
+		err := northd.Execute(&OvnCommand{operations, northd.(*ovndb), make([][]map[string]interface{}, len(operations))})
+		assert.Nil(t, err)
+	}, QueueConfig{Size: 10})
+	defer sub.Unsubscribe()
+
+	withGlobalRow(t, ovndbapi, func() {
+		cmd, err := ovndbapi.LSAdd(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err = ovndbapi.ExecuteWait(ctx, WaitSB, cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() {
+			cmd, err := ovndbapi.LSDel(LSW)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = ovndbapi.Execute(cmd)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}()
+		ls, err := ovndbapi.LSGet(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, len(ls))
+
+		// each wait bumps nb_cfg
+		err = ovndbapi.ExecuteWait(ctx, WaitSB)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ovn := ovndbapi.(*ovndb)
+		_, global, err := ovn.globalRow()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 2, global.Fields["nb_cfg"])
+		assert.Equal(t, 2, global.Fields["sb_cfg"])
+
+		ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err = ovndbapi.ExecuteWait(ctx, WaitHV)
+		assert.Equal(t, context.DeadlineExceeded, err)
+	})
+
+	sbapi := getOVNClient(DBSB)
+	defer sbapi.Close()
+	err := sbapi.ExecuteWait(context.Background(), WaitSB)
+	assert.True(t, isError(err, ErrorOption), err)
+}
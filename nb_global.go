@@ -16,6 +16,8 @@
 
 package goovn
 
+import "github.com/ebay/libovsdb"
+
 type NBGlobalTableRow struct {
 	UUID        string
 	Options     map[interface{}]interface{}
@@ -23,6 +25,9 @@ type NBGlobalTableRow struct {
 	Connections []string
 	SSL         string
 	IPSec       bool
+	NbCfg       int
+	SbCfg       int
+	HvCfg       int
 }
 
 func (odbi *ovndb) nbGlobalAddImp(options map[string]string) (*OvnCommand, error) {
@@ -43,3 +48,31 @@ func (odbi *ovndb) nbGlobalSetOptionsImp(options map[string]string) (*OvnCommand
 func (odbi *ovndb) nbGlobalGetOptionsImp() (map[string]string, error) {
 	return odbi.globalGetOptionsImp(TableNBGlobal)
 }
+
+func (odbi *ovndb) rowToNBGlobal(uuid string) *NBGlobalTableRow {
+	cacheGlobal, ok := odbi.cache[TableNBGlobal][uuid]
+	if !ok {
+		return nil
+	}
+	options, _ := cacheGlobal.Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := cacheGlobal.Fields["external_ids"].(libovsdb.OvsMap)
+	ssl, _ := cacheGlobal.Fields["ssl"].(libovsdb.UUID)
+	ipsec, _ := cacheGlobal.Fields["ipsec"].(bool)
+	global := &NBGlobalTableRow{
+		UUID:       uuid,
+		Options:    options.GoMap,
+		ExternalID: extIDs.GoMap,
+		SSL:        ssl.GoUUID,
+		IPSec:      ipsec,
+	}
+	switch connections := cacheGlobal.Fields["connections"].(type) {
+	case libovsdb.UUID:
+		global.Connections = []string{connections.GoUUID}
+	case libovsdb.OvsSet:
+		global.Connections = odbi.ConvertGoSetToStringArray(connections)
+	}
+	global.NbCfg, _ = cacheGlobal.Fields["nb_cfg"].(int)
+	global.SbCfg, _ = cacheGlobal.Fields["sb_cfg"].(int)
+	global.HvCfg, _ = cacheGlobal.Fields["hv_cfg"].(int)
+	return global
+}
@@ -17,9 +17,11 @@
 package goovn
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 
 	"github.com/ebay/libovsdb"
@@ -40,15 +42,131 @@ var (
 	ErrorExist = errors.New("object exist")
 	// ErrorNoChanges used when function called, but no changes
 	ErrorNoChanges = errors.New("no changes requested")
+
+	// ErrorConflict used when rows changed in ovnnb/sb since they were read from the cache
+	ErrorConflict = errors.New("conflicting change in database")
+	// ErrorNotMonitored used when a table or column needed is left out by Config.TableCols
+	ErrorNotMonitored = errors.New("not monitored")
+	// ErrorNotConnected used when the client is not connected, e.g. after reconnecting failed
+	ErrorNotConnected = errors.New("not connected")
 )
 
+// kindError is an error of one of the kinds above with details, whose Unwrap returns
+// the kind, so that errors.Is(err, ErrorNotFound) holds for go 1.13 and later
+type kindError struct {
+	kind    error
+	details string
+}
+
+// errorf returns an error of kind with the details formatted from format and args
+func errorf(kind error, format string, args ...interface{}) error {
+	return &kindError{kind, fmt.Sprintf(format, args...)}
+}
+
+func (e *kindError) Error() string {
+	return e.kind.Error() + ": " + e.details
+}
+
+func (e *kindError) Unwrap() error {
+	return e.kind
+}
+
+// isError tells whether err is target or wraps it, like errors.Is, which go 1.12 lacks
+func isError(err, target error) bool {
+	for err != nil {
+		if err == target {
+			return true
+		}
+		wrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return false
+		}
+		err = wrapper.Unwrap()
+	}
+	return false
+}
+
 // OVNRow ovn nb/sb row
 type OVNRow map[string]interface{}
 
-func (odbi *ovndb) getRowUUIDs(table string, row OVNRow) []string {
+// isMonitored tells whether column of table is in the cache
+func (odbi *ovndb) isMonitored(table, column string) bool {
+	columns, ok := odbi.tableCols[table]
+	if !ok {
+		return false
+	}
+	if len(columns) == 0 || column == "_uuid" {
+		return true
+	}
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// getClient returns the connection, the one of the client of a transaction,
+// ErrorNotConnected while disconnected or reconnecting
+func (odbi *ovndb) getClient() (*ovsdbClient, error) {
+	if odbi.txn != nil {
+		return odbi.txn.parent.getClient()
+	}
+	odbi.connmutex.Lock()
+	defer odbi.connmutex.Unlock()
+	if !odbi.connected || odbi.client == nil {
+		return nil, ErrorNotConnected
+	}
+	return odbi.client, nil
+}
+
+// getSchema returns the schema of the database cached by the connection, the one of the
+// client of a transaction, ok is false while not connected
+func (odbi *ovndb) getSchema() (libovsdb.DatabaseSchema, bool) {
+	if odbi.txn != nil {
+		return odbi.txn.parent.getSchema()
+	}
+	odbi.connmutex.Lock()
+	client := odbi.client
+	odbi.connmutex.Unlock()
+	if client == nil {
+		return libovsdb.DatabaseSchema{}, false
+	}
+	return client.getSchemaCached(odbi.db)
+}
+
+// requireTable returns ErrorNotMonitored if table is not in the cache, because it is left
+// out by Config.TableCols or only monitored on request
+func (odbi *ovndb) requireTable(table string) error {
+	if _, ok := odbi.tableCols[table]; !ok {
+		return errorf(ErrorNotMonitored, "table %s", table)
+	}
+	return nil
+}
+
+// requireColumns returns ErrorNotMonitored if table or some of its columns are not in the cache
+func (odbi *ovndb) requireColumns(table string, columns ...string) error {
+	if err := odbi.requireTable(table); err != nil {
+		return err
+	}
+	for _, column := range columns {
+		if !odbi.isMonitored(table, column) {
+			return errorf(ErrorNotMonitored, "%s of table %s", column, table)
+		}
+	}
+	return nil
+}
+
+func (odbi *ovndb) getRowUUIDs(table string, row OVNRow) ([]string, error) {
 	var uuids []string
 	var wildcard bool
 
+	for field := range row {
+		if err := odbi.requireColumns(table, field); err != nil {
+			return nil, err
+		}
+	}
+
 	if reflect.DeepEqual(row, make(OVNRow)) {
 		wildcard = true
 	}
@@ -58,7 +176,7 @@ func (odbi *ovndb) getRowUUIDs(table string, row OVNRow) []string {
 
 	cacheTable, ok := odbi.cache[table]
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
 	for uuid, drows := range cacheTable {
@@ -81,19 +199,19 @@ func (odbi *ovndb) getRowUUIDs(table string, row OVNRow) []string {
 		}
 	}
 
-	return uuids
+	return uuids, nil
 }
 
-func (odbi *ovndb) getRowUUID(table string, row OVNRow) string {
-	uuids := odbi.getRowUUIDs(table, row)
+func (odbi *ovndb) getRowUUID(table string, row OVNRow) (string, error) {
+	uuids, err := odbi.getRowUUIDs(table, row)
 	if len(uuids) > 0 {
-		return uuids[0]
+		return uuids[0], nil
 	}
-	return ""
+	return "", err
 }
 
-//test if map s contains t
-//This function is not both s and t are nil at same time
+// test if map s contains t
+// This function is not both s and t are nil at same time
 func (odbi *ovndb) oMapContians(s, t map[interface{}]interface{}) bool {
 	if s == nil || t == nil {
 		return false
@@ -110,6 +228,9 @@ func (odbi *ovndb) oMapContians(s, t map[interface{}]interface{}) bool {
 }
 
 func (odbi *ovndb) getRowUUIDContainsUUID(table, field, uuid string) (string, error) {
+	if err := odbi.requireColumns(table, field); err != nil {
+		return "", err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -128,6 +249,9 @@ func (odbi *ovndb) getRowUUIDContainsUUID(table, field, uuid string) (string, er
 }
 
 func (odbi *ovndb) getRowsMatchingUUID(table, field, uuid string) ([]string, error) {
+	if err := odbi.requireColumns(table, field); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.Lock()
 	defer odbi.cachemutex.Unlock()
 	var uuids []string
@@ -143,11 +267,19 @@ func (odbi *ovndb) getRowsMatchingUUID(table, field, uuid string) ([]string, err
 	return uuids, nil
 }
 
-func (odbi *ovndb) transact(db string, ops ...libovsdb.Operation) ([]libovsdb.OperationResult, error) {
+func (odbi *ovndb) transact(ctx context.Context, db string, ops ...libovsdb.Operation) ([]libovsdb.OperationResult, error) {
 	// Only support one trans at same time now.
-	odbi.tranmutex.Lock()
-	defer odbi.tranmutex.Unlock()
-	reply, err := odbi.client.Transact(db, ops...)
+	select {
+	case odbi.tranlock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	defer func() { <-odbi.tranlock }()
+	client, err := odbi.getClient()
+	if err != nil {
+		return nil, err
+	}
+	reply, err := client.transact(ctx, db, ops...)
 
 	if err != nil {
 		return reply, err
@@ -157,6 +289,9 @@ func (odbi *ovndb) transact(db string, ops ...libovsdb.Operation) ([]libovsdb.Op
 	// maps one-to-one with operations array in the transact request object. We need to check
 	// each of the operation result for null error to ensure that the transaction has succeeded.
 	for i, o := range reply {
+		if o.Error == "timed out" && i < len(ops) && ops[i].Op == opWait {
+			return nil, ErrorConflict
+		}
 		if o.Error != "" {
 			// Per RFC 7047 Section 4.1.3, if all of the operations succeed, but the results
 			// cannot be committed, then "result" will have one more element than "params",
@@ -176,23 +311,221 @@ func (odbi *ovndb) transact(db string, ops ...libovsdb.Operation) ([]libovsdb.Op
 }
 
 func (odbi *ovndb) execute(cmds ...*OvnCommand) error {
+	return odbi.executeImp(context.Background(), odbi.verify, cmds...)
+}
+
+func (odbi *ovndb) executeContext(ctx context.Context, cmds ...*OvnCommand) error {
+	return odbi.executeImp(ctx, odbi.verify, cmds...)
+}
+
+// executeImp sends cmds in one transaction, preceded by wait operations for the
+// cached rows they depend on if verify is set.
+func (odbi *ovndb) executeImp(ctx context.Context, verify bool, cmds ...*OvnCommand) error {
 	if cmds == nil {
 		return nil
 	}
+	if odbi.txn != nil {
+		return odbi.txn.stage(cmds...)
+	}
 	var ops []libovsdb.Operation
 	for _, cmd := range cmds {
 		if cmd != nil {
 			ops = append(ops, cmd.Operations...)
 		}
 	}
+	var waits []libovsdb.Operation
+	if verify {
+		var err error
+		if waits, err = odbi.waitOperations(ops...); err != nil {
+			return err
+		}
+		ops = append(waits, ops...)
+	}
 
-	_, err := odbi.transact(odbi.db, ops...)
+	reply, err := odbi.transact(ctx, odbi.db, ops...)
 	if err != nil {
 		return err
 	}
+
+	// hand each command the results of its operations
+	reply = reply[len(waits):]
+	for _, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		cmd.Results = make([][]map[string]interface{}, len(cmd.Operations))
+		for i, op := range cmd.Operations {
+			if len(reply) == 0 {
+				break
+			}
+			cmd.Results[i] = operationResult(op, reply[0])
+			reply = reply[1:]
+		}
+	}
+	return nil
+}
+
+// operationResult returns the rows selected by a select operation, or a single row
+// with the "uuid" of the row inserted by an insert operation or the "count" of rows
+// changed by an update, mutate or delete operation.
+func operationResult(op libovsdb.Operation, result libovsdb.OperationResult) []map[string]interface{} {
+	switch op.Op {
+	case opSelect:
+		rows := make([]map[string]interface{}, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			rows = append(rows, map[string]interface{}(row))
+		}
+		return rows
+	case opInsert:
+		return []map[string]interface{}{{"uuid": result.UUID.GoUUID}}
+	case opUpdate, opMutate, opDelete:
+		return []map[string]interface{}{{"count": result.Count}}
+	}
 	return nil
 }
 
+// waitOperations returns wait operations, to be put at the start of the transaction, which
+// fail with ErrorConflict unless the rows selected by ops are still as in the cache:
+// updated rows must have the same version (or, without one, the same updated columns),
+// mutated and deleted rows must still be the ones selected, and no row may have the
+// name of an inserted row, unless an earlier operation may have removed or renamed it.
+// See RFC 7047 section 5.2.6.
+func (odbi *ovndb) waitOperations(ops ...libovsdb.Operation) ([]libovsdb.Operation, error) {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var waits []libovsdb.Operation
+	changed := make(map[string]bool)
+	for _, op := range ops {
+		if _, ok := odbi.tableCols[op.Table]; !ok {
+			// nothing is known about tables that are not monitored
+			continue
+		}
+		// a monitored table without rows has no entry in the cache
+		cacheTable := odbi.cache[op.Table]
+		switch op.Op {
+		case opInsert:
+			name, ok := op.Row["name"].(string)
+			if !ok || changed[op.Table] || !odbi.isMonitored(op.Table, "name") {
+				continue
+			}
+			where := []interface{}{libovsdb.NewCondition("name", "==", name)}
+			waits = append(waits, newWaitOperation(op.Table, where, []string{"name"}, nil))
+		case opUpdate, opMutate, opDelete:
+			if op.Op != opMutate {
+				changed[op.Table] = true
+			}
+			if hasNamedUUID(op.Where) {
+				// selects a row inserted by this transaction
+				continue
+			}
+			if !odbi.conditionsMonitored(op.Table, op.Where) {
+				// the cache cannot tell which rows are selected
+				continue
+			}
+			uuids, err := matchRows(cacheTable, op.Where)
+			if err != nil {
+				return nil, err
+			}
+			columns := []string{"_uuid"}
+			if op.Op == opUpdate {
+				versioned := true
+				for _, uuid := range uuids {
+					if _, ok := cacheTable[uuid].Fields["_version"]; !ok {
+						versioned = false
+					}
+				}
+				if versioned {
+					columns = append(columns, "_version")
+				} else {
+					for column := range op.Row {
+						if odbi.isMonitored(op.Table, column) {
+							columns = append(columns, column)
+						}
+					}
+				}
+			}
+			rows := make([]map[string]interface{}, 0, len(uuids))
+			for _, uuid := range uuids {
+				row := map[string]interface{}{"_uuid": stringToGoUUID(uuid)}
+				for _, column := range columns[1:] {
+					row[column] = datumToJSON(cacheTable[uuid].Fields[column])
+				}
+				rows = append(rows, row)
+			}
+			waits = append(waits, newWaitOperation(op.Table, op.Where, columns, rows))
+		}
+	}
+	return waits, nil
+}
+
+// newWaitOperation returns a wait for rows, which are sent even if empty, see operation
+func newWaitOperation(table string, where []interface{}, columns []string, rows []map[string]interface{}) libovsdb.Operation {
+	return libovsdb.Operation{
+		Op:      opWait,
+		Table:   table,
+		Where:   where,
+		Columns: columns,
+		Until:   "==",
+		Rows:    rows,
+		// libovsdb omits a zero timeout, which would make the server wait forever
+		Timeout: 1,
+	}
+}
+
+// conditionsMonitored tells whether all the columns of the conditions are in the cache
+func (odbi *ovndb) conditionsMonitored(table string, where []interface{}) bool {
+	for _, c := range where {
+		cond, ok := c.([]interface{})
+		if !ok || len(cond) != 3 {
+			return false
+		}
+		if column, ok := cond[0].(string); !ok || !odbi.isMonitored(table, column) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasNamedUUID(where []interface{}) bool {
+	for _, c := range where {
+		if cond, ok := c.([]interface{}); ok && len(cond) == 3 {
+			datum, err := normalizeDatum(cond[2])
+			if err != nil {
+				return true
+			}
+			for _, atom := range datumToAtoms(datum) {
+				if uuid, ok := atom.(libovsdb.UUID); ok && !isRealUUID(uuid.GoUUID) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+var realUUID = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func isRealUUID(uuid string) bool {
+	return realUUID.MatchString(uuid)
+}
+
+// datumToJSON returns a cached value in a form that marshals to valid OVSDB notation,
+// libovsdb encodes empty sets and maps with a null instead of an empty array.
+func datumToJSON(datum interface{}) interface{} {
+	switch v := datum.(type) {
+	case libovsdb.OvsSet:
+		return []interface{}{"set", append(make([]interface{}, 0, len(v.GoSet)), v.GoSet...)}
+	case libovsdb.OvsMap:
+		pairs := make([]interface{}, 0, len(v.GoMap))
+		for key, value := range v.GoMap {
+			pairs = append(pairs, []interface{}{key, value})
+		}
+		return []interface{}{"map", pairs}
+	}
+	return datum
+}
+
 func (odbi *ovndb) float64_to_int(row libovsdb.Row) {
 	for field, value := range row.Fields {
 		if v, ok := value.(float64); ok {
@@ -206,10 +539,9 @@ func (odbi *ovndb) float64_to_int(row libovsdb.Row) {
 
 func (odbi *ovndb) populateCache(updates libovsdb.TableUpdates) {
 	empty := libovsdb.Row{}
+	var events, deleted []Event
 
 	odbi.cachemutex.Lock()
-	defer odbi.cachemutex.Unlock()
-
 	for table := range odbi.tableCols {
 		tableUpdate, ok := updates.Updates[table]
 		if !ok {
@@ -229,106 +561,130 @@ func (odbi *ovndb) populateCache(updates libovsdb.TableUpdates) {
 					// Already existed and unchanged, ignore (this can happen when auto-reconnect)
 					continue
 				}
+				event := Event{Type: EventCreate, Table: table, UUID: uuid}
+				if _, ok := odbi.cache[table][uuid]; ok {
+					event.Type = EventUpdate
+					event.Old = odbi.rowToObject(table, uuid)
+				}
 				odbi.cache[table][uuid] = row.New
+				event.New = odbi.rowToObject(table, uuid)
+				events = append(events, event)
+			} else if _, ok := odbi.cache[table][uuid]; ok {
+				// rows are deleted once all are converted, which may look up the deleted ones
+				deleted = append(deleted, Event{Type: EventDelete, Table: table, UUID: uuid})
+			}
+		}
+	}
+	for i := len(deleted) - 1; i >= 0; i-- {
+		deleted[i].Old = odbi.rowToObject(deleted[i].Table, deleted[i].UUID)
+		delete(odbi.cache[deleted[i].Table], deleted[i].UUID)
+		events = append(events, deleted[i])
+	}
+	odbi.cachemutex.Unlock()
 
-				if odbi.signalCB != nil {
-					switch table {
-					case TableLogicalRouter:
-						lr := odbi.rowToLogicalRouter(uuid)
-						odbi.signalCB.OnLogicalRouterCreate(lr)
-					case TableLogicalRouterPort:
-						lrp := odbi.rowToLogicalRouterPort(uuid)
-						odbi.signalCB.OnLogicalRouterPortCreate(lrp)
-					case TableLogicalRouterStaticRoute:
-						lrsr := odbi.rowToLogicalRouterStaticRoute(uuid)
-						odbi.signalCB.OnLogicalRouterStaticRouteCreate(lrsr)
-					case TableLogicalSwitch:
-						ls := odbi.rowToLogicalSwitch(uuid)
-						odbi.signalCB.OnLogicalSwitchCreate(ls)
-					case TableLogicalSwitchPort:
-						lp, err := odbi.rowToLogicalPort(uuid)
-						if err == nil {
-							odbi.signalCB.OnLogicalPortCreate(lp)
-						}
-					case TableACL:
-						acl := odbi.rowToACL(uuid)
-						odbi.signalCB.OnACLCreate(acl)
-					case TableDHCPOptions:
-						dhcp := odbi.rowToDHCPOptions(uuid)
-						odbi.signalCB.OnDHCPOptionsCreate(dhcp)
-					case TableQoS:
-						qos := odbi.rowToQoS(uuid)
-						odbi.signalCB.OnQoSCreate(qos)
-					case TableLoadBalancer:
-						lb, _ := odbi.rowToLB(uuid)
-						odbi.signalCB.OnLoadBalancerCreate(lb)
-					case TableMeter:
-						meter := odbi.rowToMeter(uuid)
-						odbi.signalCB.OnMeterCreate(meter)
-					case TableMeterBand:
-						band, _ := odbi.rowToMeterBand(uuid)
-						odbi.signalCB.OnMeterBandCreate(band)
-					case TableChassis:
-						chassis, _ := odbi.rowToChassis(uuid)
-						odbi.signalCB.OnChassisCreate(chassis)
-					case TableEncap:
-						encap, _ := odbi.rowToEncap(uuid)
-						odbi.signalCB.OnEncapCreate(encap)
-					}
-				}
-			} else {
-				defer delete(odbi.cache[table], uuid)
-
-				if odbi.signalCB != nil {
-					defer func(table, uuid string) {
-						switch table {
-						case TableLogicalRouter:
-							lr := odbi.rowToLogicalRouter(uuid)
-							odbi.signalCB.OnLogicalRouterDelete(lr)
-						case TableLogicalRouterPort:
-							lrp := odbi.rowToLogicalRouterPort(uuid)
-							odbi.signalCB.OnLogicalRouterPortDelete(lrp)
-						case TableLogicalRouterStaticRoute:
-							lrsr := odbi.rowToLogicalRouterStaticRoute(uuid)
-							odbi.signalCB.OnLogicalRouterStaticRouteDelete(lrsr)
-						case TableLogicalSwitch:
-							ls := odbi.rowToLogicalSwitch(uuid)
-							odbi.signalCB.OnLogicalSwitchDelete(ls)
-						case TableLogicalSwitchPort:
-							lp, err := odbi.rowToLogicalPort(uuid)
-							if err == nil {
-								odbi.signalCB.OnLogicalPortDelete(lp)
-							}
-						case TableACL:
-							acl := odbi.rowToACL(uuid)
-							odbi.signalCB.OnACLDelete(acl)
-						case TableDHCPOptions:
-							dhcp := odbi.rowToDHCPOptions(uuid)
-							odbi.signalCB.OnDHCPOptionsDelete(dhcp)
-						case TableQoS:
-							qos := odbi.rowToQoS(uuid)
-							odbi.signalCB.OnQoSDelete(qos)
-						case TableLoadBalancer:
-							lb, _ := odbi.rowToLB(uuid)
-							odbi.signalCB.OnLoadBalancerDelete(lb)
-						case TableMeter:
-							meter := odbi.rowToMeter(uuid)
-							odbi.signalCB.OnMeterDelete(meter)
-						case TableMeterBand:
-							band, _ := odbi.rowToMeterBand(uuid)
-							odbi.signalCB.OnMeterBandDelete(band)
-						case TableChassis:
-							chassis, _ := odbi.rowToChassis(uuid)
-							odbi.signalCB.OnChassisDelete(chassis)
-						case TableEncap:
-							encap, _ := odbi.rowToEncap(uuid)
-							odbi.signalCB.OnEncapDelete(encap)
-						}
-					}(table, uuid)
-				}
+	// subscribers may use the cache
+	odbi.publish(events)
+}
+
+// resyncCache replaces the cache with the initial rows of a new monitor, the rows deleted
+// while disconnected are notified as deleted and the others as created or updated if changed
+func (odbi *ovndb) resyncCache(initial libovsdb.TableUpdates) {
+	odbi.cachemutex.RLock()
+	for table, rows := range odbi.cache {
+		tableUpdate, ok := initial.Updates[table]
+		if !ok {
+			tableUpdate = libovsdb.TableUpdate{Rows: make(map[string]libovsdb.RowUpdate)}
+			initial.Updates[table] = tableUpdate
+		}
+		for uuid, row := range rows {
+			if _, ok := tableUpdate.Rows[uuid]; !ok {
+				tableUpdate.Rows[uuid] = libovsdb.RowUpdate{Old: row}
 			}
 		}
 	}
+	odbi.cachemutex.RUnlock()
+
+	// the connection is not registered yet, no update can come in between
+	odbi.populateCache(initial)
+}
+
+// rowToObject converts the cached row of table to the object the events hold, the row
+// itself for tables that have none, which the tables the library monitors all have
+func (odbi *ovndb) rowToObject(table, uuid string) interface{} {
+	switch table {
+	case TableLogicalRouter:
+		return odbi.rowToLogicalRouter(uuid)
+	case TableLogicalRouterPort:
+		return odbi.rowToLogicalRouterPort(uuid)
+	case TableLogicalRouterStaticRoute:
+		return odbi.rowToLogicalRouterStaticRoute(uuid)
+	case TableLogicalRouterPolicy:
+		return odbi.rowToLogicalRouterPolicy(uuid)
+	case TableLogicalSwitch:
+		return odbi.rowToLogicalSwitch(uuid)
+	case TableLogicalSwitchPort:
+		if lp, err := odbi.rowToLogicalPort(uuid); err == nil {
+			return lp
+		}
+		return nil
+	case TableACL:
+		return odbi.rowToACL(uuid)
+	case TableDHCPOptions:
+		return odbi.rowToDHCPOptions(uuid)
+	case TableDNS:
+		return odbi.rowToDNS(uuid)
+	case TableQoS:
+		return odbi.rowToQoS(uuid)
+	case TableLoadBalancer:
+		lb, _ := odbi.rowToLB(uuid)
+		return lb
+	case TableMeter:
+		return odbi.rowToMeter(uuid)
+	case TableMeterBand:
+		band, _ := odbi.rowToMeterBand(uuid)
+		return band
+	case TableChassis:
+		chassis, _ := odbi.rowToChassis(uuid)
+		return chassis
+	case TableChassisPrivate:
+		return odbi.rowToChassisPrivate(uuid)
+	case TableEncap:
+		encap, _ := odbi.rowToEncap(uuid)
+		return encap
+	case TablePortBinding:
+		return odbi.rowToPortBinding(uuid)
+	case TableDatapathBinding:
+		return odbi.rowToDatapathBinding(uuid)
+	case TableLogicalFlow:
+		return odbi.rowToLogicalFlow(uuid)
+	case TableLogicalDPGroup:
+		return odbi.rowToLogicalDPGroup(uuid)
+	case TableMACBinding:
+		return odbi.rowToMACBinding(uuid)
+	case TableFDB:
+		return odbi.rowToFDB(uuid)
+	case TableConnection:
+		return odbi.rowToConnection(uuid)
+	case TableSSL:
+		return odbi.rowToSSL(uuid)
+	case TableNAT:
+		return odbi.rowToNat(uuid)
+	case TableAddressSet:
+		return odbi.rowToAddressSet(uuid)
+	case TablePortGroup:
+		return odbi.RowToPortGroup(uuid)
+	case TableGatewayChassis:
+		return odbi.rowToGatewayChassis(uuid)
+	case TableHAChassis:
+		return odbi.rowToHAChassis(uuid)
+	case TableHAChassisGroup:
+		return odbi.rowToHAChassisGroup(uuid)
+	case TableNBGlobal:
+		return odbi.rowToNBGlobal(uuid)
+	case TableSBGlobal:
+		return odbi.rowToSBGlobal(uuid)
+	}
+	return odbi.cache[table][uuid]
 }
 
 func (odbi *ovndb) ConvertGoSetToStringArray(oset libovsdb.OvsSet) []string {
@@ -1,7 +1,9 @@
 package goovn
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -102,3 +104,96 @@ func TestConvertGoSetToStringArray(t *testing.T) {
 	}
 	t.Logf("Deleted the logical switch " + LSW)
 }
+
+// updateSignal records the modifications of logical switch ports
+type updateSignal struct {
+	signal
+	mutex   sync.Mutex
+	updates [][2]interface{}
+}
+
+func (s *updateSignal) record(old, new interface{}) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.updates = append(s.updates, [2]interface{}{old, new})
+}
+
+func (s *updateSignal) recorded() [][2]interface{} {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.updates
+}
+
+func (s *updateSignal) OnLogicalSwitchUpdate(old, new *LogicalSwitch)                       {}
+func (s *updateSignal) OnLogicalPortUpdate(old, new *LogicalSwitchPort)                     { s.record(old, new) }
+func (s *updateSignal) OnLogicalRouterUpdate(old, new *LogicalRouter)                       {}
+func (s *updateSignal) OnLogicalRouterPortUpdate(old, new *LogicalRouterPort)               {}
+func (s *updateSignal) OnLogicalRouterStaticRouteUpdate(old, new *LogicalRouterStaticRoute) {}
+func (s *updateSignal) OnACLUpdate(old, new *ACL)                                           {}
+func (s *updateSignal) OnDHCPOptionsUpdate(old, new *DHCPOptions)                           {}
+func (s *updateSignal) OnQoSUpdate(old, new *QoS)                                           {}
+func (s *updateSignal) OnLoadBalancerUpdate(old, new *LoadBalancer)                         {}
+func (s *updateSignal) OnMeterUpdate(old, new *Meter)                                       {}
+func (s *updateSignal) OnMeterBandUpdate(old, new *MeterBand)                               {}
+func (s *updateSignal) OnChassisUpdate(old, new *Chassis)                                   {}
+func (s *updateSignal) OnEncapUpdate(old, new *Encap)                                       {}
+
+func TestUpdateSignal(t *testing.T) {
+	cfg := buildOvnDbConfig(DBNB)
+	updates := &updateSignal{}
+	cfg.SignalCB = updates
+	api, err := NewClient(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer api.Close()
+
+	cmds := make([]*OvnCommand, 0)
+	cmd, err := api.LSAdd(LSW)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	cmd, err = api.LSPAdd(LSW, LSP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds = append(cmds, cmd)
+	err = api.Execute(cmds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := api.LSDel(LSW)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = api.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+	// created rows are not updates
+	assert.Equal(t, 0, len(updates.recorded()))
+
+	cmd, err = api.LSPSetAddress(LSP, ADDR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = api.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the notification is processed in the goroutine of the subscription
+	assert.Eventually(t, func() bool {
+		return len(updates.recorded()) > 0
+	}, time.Second, 10*time.Millisecond)
+	recorded := updates.recorded()
+	if !assert.Equal(t, 1, len(recorded)) {
+		t.FailNow()
+	}
+	old, new := recorded[0][0].(*LogicalSwitchPort), recorded[0][1].(*LogicalSwitchPort)
+	assert.Equal(t, LSP, old.Name)
+	assert.Equal(t, 0, len(old.Addresses))
+	assert.Equal(t, []string{ADDR}, new.Addresses)
+}
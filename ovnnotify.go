@@ -25,8 +25,19 @@ type ovnNotifier struct {
 }
 
 func (notify ovnNotifier) Update(context interface{}, tableUpdates libovsdb.TableUpdates) {
+	if context == dbServer {
+		notify.odbi.serverUpdate(tableUpdates)
+		return
+	}
 	notify.odbi.populateCache(tableUpdates)
 }
+func (notify ovnNotifier) update2(context interface{}, tableUpdates tableUpdates2) {
+	notify.odbi.populateCache(notify.odbi.fromTableUpdates2(tableUpdates))
+}
+func (notify ovnNotifier) update3(context interface{}, txnID string, tableUpdates tableUpdates2) {
+	notify.odbi.populateCache(notify.odbi.fromTableUpdates2(tableUpdates))
+	notify.odbi.setLastTxn(txnID)
+}
 func (notify ovnNotifier) Locked([]interface{}) {
 }
 func (notify ovnNotifier) Stolen([]interface{}) {
@@ -35,9 +46,5 @@ func (notify ovnNotifier) Echo([]interface{}) {
 }
 
 func (notify ovnNotifier) Disconnected(client *libovsdb.OvsdbClient) {
-	if notify.odbi.reconn {
-		notify.odbi.reconnect()
-	} else if notify.odbi.disconnectCB != nil {
-		notify.odbi.disconnectCB()
-	}
+	notify.odbi.disconnected()
 }
@@ -0,0 +1,220 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+
+	"github.com/ebay/libovsdb"
+)
+
+// PortBinding ovnsb item, Chassis is the uuid of the chassis the port is bound to, if any
+type PortBinding struct {
+	UUID          string
+	LogicalPort   string
+	Type          string
+	Chassis       string
+	Datapath      string
+	TunnelKey     int
+	MAC           []string
+	Options       map[interface{}]interface{}
+	ParentPort    string
+	Tag           int
+	VirtualParent string
+	Encap         string
+	NatAddresses  []string
+	ExternalID    map[interface{}]interface{}
+}
+
+// chassisUUID returns the uuid of the chassis named chassis
+func (odbi *ovndb) chassisUUID(chassis string) (string, error) {
+	row := make(OVNRow)
+	row["name"] = chassis
+	uuid, err := odbi.getRowUUID(TableChassis, row)
+	if err != nil {
+		return "", err
+	}
+	if len(uuid) == 0 {
+		return "", ErrorNotFound
+	}
+	return uuid, nil
+}
+
+func (odbi *ovndb) portBindingListImp() ([]*PortBinding, error) {
+	if err := odbi.requireTable(TablePortBinding); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	// a table without rows has no entry in the cache
+	cachePortBinding := odbi.cache[TablePortBinding]
+	listPortBinding := make([]*PortBinding, 0, len(cachePortBinding))
+	for uuid := range cachePortBinding {
+		listPortBinding = append(listPortBinding, odbi.rowToPortBinding(uuid))
+	}
+	return listPortBinding, nil
+}
+
+func (odbi *ovndb) portBindingGetImp(lport string) (*PortBinding, error) {
+	if err := odbi.requireColumns(TablePortBinding, "logical_port"); err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	for uuid, drows := range odbi.cache[TablePortBinding] {
+		if rlport, ok := drows.Fields["logical_port"].(string); ok && rlport == lport {
+			return odbi.rowToPortBinding(uuid), nil
+		}
+	}
+	return nil, ErrorNotFound
+}
+
+// portBindingWaitBoundImp returns the port binding of lport once it is bound to a chassis,
+// waiting for lport to be added and bound until ctx is done
+func (odbi *ovndb) portBindingWaitBoundImp(ctx context.Context, lport string) (*PortBinding, error) {
+	if err := odbi.requireColumns(TablePortBinding, "logical_port", "chassis"); err != nil {
+		return nil, err
+	}
+	// subscribed before the cache is checked, so that no binding is missed
+	changed := make(chan struct{}, 1)
+	sub := odbi.subscribeImp(TablePortBinding, func(Event) {
+		select {
+		case changed <- struct{}{}:
+		default:
+		}
+	}, QueueConfig{}, []EventPredicate{func(event Event) bool {
+		pb, _ := event.New.(*PortBinding)
+		return pb != nil && pb.LogicalPort == lport && len(pb.Chassis) > 0
+	}})
+	defer sub.Unsubscribe()
+
+	for {
+		pb, err := odbi.portBindingGetImp(lport)
+		if err != nil && !isError(err, ErrorNotFound) {
+			return nil, err
+		}
+		if pb != nil && len(pb.Chassis) > 0 {
+			return pb, nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// portBindingListByChassisImp returns the ports bound to the chassis named chassis
+func (odbi *ovndb) portBindingListByChassisImp(chassis string) ([]*PortBinding, error) {
+	if err := odbi.requireColumns(TablePortBinding, "chassis"); err != nil {
+		return nil, err
+	}
+	chassisUUID, err := odbi.chassisUUID(chassis)
+	if err != nil {
+		return nil, err
+	}
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	var listPortBinding []*PortBinding
+	for uuid, drows := range odbi.cache[TablePortBinding] {
+		if rchassis, ok := drows.Fields["chassis"].(libovsdb.UUID); ok && rchassis.GoUUID == chassisUUID {
+			listPortBinding = append(listPortBinding, odbi.rowToPortBinding(uuid))
+		}
+	}
+	return listPortBinding, nil
+}
+
+// portBindingSetChassisImp binds lport to the chassis named chassis, unbinds it if chassis is empty
+func (odbi *ovndb) portBindingSetChassisImp(lport, chassis string) (*OvnCommand, error) {
+	if _, err := odbi.portBindingGetImp(lport); err != nil {
+		return nil, err
+	}
+
+	row := make(OVNRow)
+	if len(chassis) == 0 {
+		row["chassis"] = libovsdb.OvsSet{GoSet: []interface{}{}}
+	} else {
+		chassisUUID, err := odbi.chassisUUID(chassis)
+		if err != nil {
+			return nil, errorf(err, "chassis %s", chassis)
+		}
+		row["chassis"] = stringToGoUUID(chassisUUID)
+	}
+	condition := libovsdb.NewCondition("logical_port", "==", lport)
+	updateOp := libovsdb.Operation{
+		Op:    opUpdate,
+		Table: TablePortBinding,
+		Row:   row,
+		Where: []interface{}{condition},
+	}
+	operations := []libovsdb.Operation{updateOp}
+	return &OvnCommand{operations, odbi, make([][]map[string]interface{}, len(operations))}, nil
+}
+
+func (odbi *ovndb) rowToPortBinding(uuid string) *PortBinding {
+	cachePortBinding, ok := odbi.cache[TablePortBinding][uuid]
+	if !ok {
+		return nil
+	}
+
+	lport, _ := cachePortBinding.Fields["logical_port"].(string)
+	ptype, _ := cachePortBinding.Fields["type"].(string)
+	tunnelKey, _ := cachePortBinding.Fields["tunnel_key"].(int)
+	options, _ := cachePortBinding.Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := cachePortBinding.Fields["external_ids"].(libovsdb.OvsMap)
+	// optional columns are empty sets when unset
+	parentPort, _ := cachePortBinding.Fields["parent_port"].(string)
+	tag, _ := cachePortBinding.Fields["tag"].(int)
+	virtualParent, _ := cachePortBinding.Fields["virtual_parent"].(string)
+
+	pb := &PortBinding{
+		UUID:          uuid,
+		LogicalPort:   lport,
+		Type:          ptype,
+		TunnelKey:     tunnelKey,
+		Options:       options.GoMap,
+		ParentPort:    parentPort,
+		Tag:           tag,
+		VirtualParent: virtualParent,
+		ExternalID:    extIDs.GoMap,
+	}
+	if chassis, ok := cachePortBinding.Fields["chassis"].(libovsdb.UUID); ok {
+		pb.Chassis = chassis.GoUUID
+	}
+	if datapath, ok := cachePortBinding.Fields["datapath"].(libovsdb.UUID); ok {
+		pb.Datapath = datapath.GoUUID
+	}
+	if encap, ok := cachePortBinding.Fields["encap"].(libovsdb.UUID); ok {
+		pb.Encap = encap.GoUUID
+	}
+	switch mac := cachePortBinding.Fields["mac"].(type) {
+	case string:
+		pb.MAC = []string{mac}
+	case libovsdb.OvsSet:
+		pb.MAC = odbi.ConvertGoSetToStringArray(mac)
+	}
+	switch natAddresses := cachePortBinding.Fields["nat_addresses"].(type) {
+	case string:
+		pb.NatAddresses = []string{natAddresses}
+	case libovsdb.OvsSet:
+		pb.NatAddresses = odbi.ConvertGoSetToStringArray(natAddresses)
+	}
+	return pb
+}
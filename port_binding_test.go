@@ -0,0 +1,227 @@
+package goovn
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	PB_DATAPATH_KEY = 10
+	PB_LPORT        = "fake-lport"
+	PB_MAC          = "00:00:00:00:00:01 10.0.0.1"
+)
+
+// sbTransact executes raw operations, for the southbound rows ovn-northd owns
+func sbTransact(t *testing.T, ovndbapi Client, operations ...libovsdb.Operation) {
+	ovn, ok := ovndbapi.(*ovndb)
+	if !ok {
+		t.Fatal(fmt.Errorf("Invalid type assertion"))
+	}
+	err := ovndbapi.Execute(&OvnCommand{operations, ovn, make([][]map[string]interface{}, len(operations))})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// addPortBinding adds lport on a new datapath with tunnel key key
+func addPortBinding(t *testing.T, ovndbapi Client, key int, lport string) {
+	dpRow := make(OVNRow)
+	dpRow["tunnel_key"] = key
+	mac, err := libovsdb.NewOvsSet([]string{PB_MAC})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbRow := make(OVNRow)
+	pbRow["logical_port"] = lport
+	pbRow["tunnel_key"] = 1
+	pbRow["datapath"] = libovsdb.UUID{GoUUID: "datapath"}
+	pbRow["mac"] = mac
+	sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opInsert, Table: TableDatapathBinding, Row: dpRow, UUIDName: "datapath"},
+		libovsdb.Operation{Op: opInsert, Table: TablePortBinding, Row: pbRow})
+}
+
+// delDatapath deletes the datapath with tunnel key key and its port bindings
+func delDatapath(t *testing.T, ovndbapi Client, key int) {
+	sbTransact(t, ovndbapi,
+		libovsdb.Operation{Op: opDelete, Table: TablePortBinding, Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", 1)}},
+		libovsdb.Operation{Op: opDelete, Table: TableDatapathBinding, Where: []interface{}{libovsdb.NewCondition("tunnel_key", "==", key)}})
+}
+
+func TestPortBinding(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	addPortBinding(t, ovndbapi, PB_DATAPATH_KEY, PB_LPORT)
+	defer delDatapath(t, ovndbapi, PB_DATAPATH_KEY)
+	cmd, err := ovndbapi.ChassisAdd(CHASSIS_NAME, CHASSIS_HOSTNAME, ENCAP_TYPES, IP, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pbs, err := ovndbapi.PortBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(pbs))
+	pb, err := ovndbapi.PortBindingGet(PB_LPORT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{PB_MAC}, pb.MAC)
+	assert.Equal(t, 1, pb.TunnelKey)
+	assert.NotEmpty(t, pb.Datapath)
+	assert.Empty(t, pb.Chassis)
+	_, err = ovndbapi.PortBindingGet(FAKENOSWITCH)
+	assert.True(t, isError(err, ErrorNotFound), err)
+
+	// wait for the port to be bound
+	bound := make(chan *PortBinding, 1)
+	sub := ovndbapi.Subscribe(TablePortBinding, func(event Event) {
+		bound <- event.New.(*PortBinding)
+	}, func(event Event) bool {
+		return event.Type == EventUpdate && event.New.(*PortBinding).Chassis != ""
+	})
+	defer sub.Unsubscribe()
+
+	cmd, err = ovndbapi.PortBindingSetChassis(PB_LPORT, CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chassis, err := ovndbapi.ChassisGet(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, chassis[0].UUID, (<-bound).Chassis)
+	pbs, err = ovndbapi.PortBindingListByChassis(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(t, 1, len(pbs)) {
+		assert.Equal(t, PB_LPORT, pbs[0].LogicalPort)
+	}
+	_, err = ovndbapi.PortBindingSetChassis(PB_LPORT, FAKENOCHASSIS)
+	assert.True(t, isError(err, ErrorNotFound), err)
+	_, err = ovndbapi.PortBindingListByChassis(FAKENOCHASSIS)
+	assert.True(t, isError(err, ErrorNotFound), err)
+
+	cmd, err = ovndbapi.PortBindingSetChassis(PB_LPORT, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pbs, err = ovndbapi.PortBindingListByChassis(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(pbs))
+
+	// the chassis is a weak reference, deleting the chassis unbinds the port
+	cmd, err = ovndbapi.PortBindingSetChassis(PB_LPORT, CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = ovndbapi.ChassisDel(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pb, err = ovndbapi.PortBindingGet(PB_LPORT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, pb.Chassis)
+}
+
+func TestPortBindingWaitBound(t *testing.T) {
+	ovndbapi := getOVNClient(DBSB)
+	defer ovndbapi.Close()
+
+	// no port binding is not an error
+	pbs, err := ovndbapi.PortBindingList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 0, len(pbs))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = ovndbapi.PortBindingWaitBound(ctx, PB_LPORT)
+	assert.True(t, isError(err, context.DeadlineExceeded), err)
+
+	cmd, err := ovndbapi.ChassisAdd(CHASSIS_NAME, CHASSIS_HOSTNAME, ENCAP_TYPES, IP, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		cmd, err := ovndbapi.ChassisDel(CHASSIS_NAME)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ovndbapi.Execute(cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	// the port is added and bound while the binding is waited for
+	bound := make(chan *PortBinding, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		pb, err := ovndbapi.PortBindingWaitBound(ctx, PB_LPORT)
+		assert.Nil(t, err)
+		bound <- pb
+	}()
+	addPortBinding(t, ovndbapi, PB_DATAPATH_KEY, PB_LPORT)
+	defer delDatapath(t, ovndbapi, PB_DATAPATH_KEY)
+	cmd, err = ovndbapi.PortBindingSetChassis(PB_LPORT, CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ovndbapi.Execute(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chassis, err := ovndbapi.ChassisGet(CHASSIS_NAME)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pb := <-bound; assert.NotNil(t, pb) {
+		assert.Equal(t, chassis[0].UUID, pb.Chassis)
+	}
+
+	// a bound port is returned at once
+	pb, err := ovndbapi.PortBindingWaitBound(context.Background(), PB_LPORT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, chassis[0].UUID, pb.Chassis)
+}
@@ -40,7 +40,9 @@ func (odbi *ovndb) pgAddImp(group string, ports []string, external_ids map[strin
 	row := make(OVNRow)
 	row["name"] = group
 
-	if uuid := odbi.getRowUUID(TablePortGroup, row); len(uuid) > 0 {
+	if uuid, err := odbi.getRowUUID(TablePortGroup, row); err != nil {
+		return nil, err
+	} else if len(uuid) > 0 {
 		return nil, ErrorExist
 	}
 
@@ -78,7 +80,9 @@ func (odbi *ovndb) pgUpdateImp(group string, ports []string, external_ids map[st
 	row := make(OVNRow)
 	row["name"] = group
 
-	if uuid := odbi.getRowUUID(TablePortGroup, row); len(uuid) == 0 {
+	if uuid, err := odbi.getRowUUID(TablePortGroup, row); err != nil {
+		return nil, err
+	} else if len(uuid) == 0 {
 		return nil, ErrorNotFound
 	}
 
@@ -129,6 +133,9 @@ func (odbi *ovndb) pgDelImp(group string) (*OvnCommand, error) {
 }
 
 func (odbi *ovndb) pgGetImp(pg string) (*PortGroup, error) {
+	if err := odbi.requireColumns(TablePortGroup, "name"); err != nil {
+		return nil, err
+	}
 	var pgList []*PortGroup
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
@@ -158,10 +165,12 @@ func (odbi *ovndb) RowToPortGroup(uuid string) *PortGroup {
 	if !ok {
 		return nil
 	}
+	name, _ := cachePortGroup.Fields["name"].(string)
+	extIDs, _ := cachePortGroup.Fields["external_ids"].(libovsdb.OvsMap)
 	pg := &PortGroup{
 		UUID:       uuid,
-		Name:       cachePortGroup.Fields["name"].(string),
-		ExternalID: cachePortGroup.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Name:       name,
+		ExternalID: extIDs.GoMap,
 	}
 	ports := cachePortGroup.Fields["ports"]
 	switch ports.(type) {
@@ -181,6 +190,9 @@ func (odbi *ovndb) RowToPortGroup(uuid string) *PortGroup {
 }
 
 func (odbi *ovndb) GetLogicalPortsByPortGroup(group string) ([]*LogicalSwitchPort, error) {
+	if err := odbi.requireColumns(TablePortGroup, "name", "ports"); err != nil {
+		return nil, err
+	}
 	var listLSP []*LogicalSwitchPort
 
 	odbi.cachemutex.RLock()
@@ -40,20 +40,30 @@ func (odbi *ovndb) rowToQoS(uuid string) *QoS {
 		return nil
 	}
 
+	priority, _ := cacheQoS.Fields["priority"].(int)
+	direction, _ := cacheQoS.Fields["direction"].(string)
+	match, _ := cacheQoS.Fields["match"].(string)
+	action, _ := cacheQoS.Fields["action"].(libovsdb.OvsMap)
+	bandwidth, _ := cacheQoS.Fields["bandwidth"].(libovsdb.OvsMap)
+	extIDs, _ := cacheQoS.Fields["external_ids"].(libovsdb.OvsMap)
+
 	qos := &QoS{
 		UUID:       uuid,
-		Priority:   cacheQoS.Fields["priority"].(int),
-		Direction:  cacheQoS.Fields["direction"].(string),
-		Match:      cacheQoS.Fields["match"].(string),
-		Action:     cacheQoS.Fields["action"].(libovsdb.OvsMap).GoMap,
-		Bandwidth:  cacheQoS.Fields["bandwidth"].(libovsdb.OvsMap).GoMap,
-		ExternalID: cacheQoS.Fields["external_ids"].(libovsdb.OvsMap).GoMap,
+		Priority:   priority,
+		Direction:  direction,
+		Match:      match,
+		Action:     action.GoMap,
+		Bandwidth:  bandwidth.GoMap,
+		ExternalID: extIDs.GoMap,
 	}
 
 	return qos
 }
 
 func (odbi *ovndb) qosAddImp(ls string, direction string, priority int, match string, action map[string]int, bandwidth map[string]int, external_ids map[string]string) (*OvnCommand, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "qos_rules"); err != nil {
+		return nil, err
+	}
 	namedUUID, err := newRowUUID()
 	if err != nil {
 		return nil, err
@@ -128,7 +138,10 @@ func (odbi *ovndb) qosDelImp(ls string, direction string, priority int, match st
 		row["match"] = match
 	}
 
-	selUUIDs := odbi.getRowUUIDs(TableQoS, row)
+	selUUIDs, err := odbi.getRowUUIDs(TableQoS, row)
+	if err != nil {
+		return nil, err
+	}
 	if len(selUUIDs) == 0 && !reflect.DeepEqual(row, make(OVNRow)) {
 		return nil, ErrorNotFound
 	}
@@ -173,6 +186,9 @@ func (odbi *ovndb) qosDelImp(ls string, direction string, priority int, match st
 }
 
 func (odbi *ovndb) qosListImp(ls string) ([]*QoS, error) {
+	if err := odbi.requireColumns(TableLogicalSwitch, "name", "qos_rules"); err != nil {
+		return nil, err
+	}
 	odbi.cachemutex.RLock()
 	defer odbi.cachemutex.RUnlock()
 
@@ -0,0 +1,521 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cenkalti/rpc2/jsonrpc"
+	"github.com/ebay/libovsdb"
+)
+
+const (
+	defaultTCPAddress  = "127.0.0.1:6641"
+	defaultUnixAddress = "/var/run/openvswitch/ovnnb_db.sock"
+	protoSSL           = "ssl"
+	protoTCP           = "tcp"
+	protoUnix          = "unix"
+)
+
+// ovsdbClient is a JSON-RPC connection to an ovsdb-server, see RFC 7047 section 4.
+// Unlike libovsdb.OvsdbClient every call takes a context, so that a hung server
+// cannot block the caller forever.
+type ovsdbClient struct {
+	// unix nanoseconds of the last traffic received, first for 64-bit alignment of atomics
+	lastRecv int64
+	// notifications being handled, during which the probe sees no inactivity
+	handling int32
+	rpc      *rpc2.Client
+	schema   map[string]libovsdb.DatabaseSchema
+	notifier OVNNotifier
+	// notifications received while holding, see register
+	held    []func(OVNNotifier)
+	holding bool
+	// protects notifier, held and holding
+	mutex sync.Mutex
+}
+
+// dial connects to endpoint in ovsdb connection method format (unix:FILE, tcp:IP:PORT
+// or ssl:IP:PORT), see connectMember for a comma separated list of them
+func dial(ctx context.Context, endpoint string, tlsConfig *tls.Config) (*ovsdbClient, error) {
+	conn, err := dialEndpoint(ctx, strings.TrimSpace(endpoint), tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %s", endpoint, err.Error())
+	}
+	return newOvsdbClient(conn), nil
+}
+
+func dialEndpoint(ctx context.Context, endpoint string, tlsConfig *tls.Config) (net.Conn, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var host string
+	if strs := strings.SplitN(endpoint, ":", 2); len(strs) == 2 {
+		host = strs[1]
+	}
+	var dialer net.Dialer
+	switch u.Scheme {
+	case protoUnix:
+		path := u.Path
+		if len(path) == 0 {
+			path = defaultUnixAddress
+		}
+		return dialer.DialContext(ctx, protoUnix, path)
+	case protoTCP:
+		if len(host) == 0 {
+			host = defaultTCPAddress
+		}
+		return dialer.DialContext(ctx, protoTCP, host)
+	case protoSSL:
+		if len(host) == 0 {
+			host = defaultTCPAddress
+		}
+		conn, err := dialer.DialContext(ctx, protoTCP, host)
+		if err != nil {
+			return nil, err
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		// the handshake is bounded by the deadline of ctx, not cancelled with it
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		if err = tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn.SetDeadline(time.Time{})
+		return tlsConn, nil
+	}
+	return nil, fmt.Errorf("unknown network protocol %s", u.Scheme)
+}
+
+// activityConn records the time of the last read of ovsdbClient that returned data
+type activityConn struct {
+	net.Conn
+	c *ovsdbClient
+}
+
+func (conn activityConn) Read(b []byte) (int, error) {
+	n, err := conn.Conn.Read(b)
+	if n > 0 {
+		conn.c.received()
+	}
+	return n, err
+}
+
+func newOvsdbClient(conn net.Conn) *ovsdbClient {
+	c := &ovsdbClient{
+		lastRecv: time.Now().UnixNano(),
+		schema:   make(map[string]libovsdb.DatabaseSchema),
+	}
+	c.rpc = rpc2.NewClientWithCodec(jsonrpc.NewJSONCodec(activityConn{conn, c}))
+	c.rpc.SetBlocking(true)
+	c.rpc.Handle("echo", c.echo)
+	c.rpc.Handle("update", c.update)
+	c.rpc.Handle("update2", c.update2)
+	c.rpc.Handle("update3", c.update3)
+	go c.rpc.Run()
+	go func() {
+		<-c.rpc.DisconnectNotify()
+		c.notify(func(notifier OVNNotifier) {
+			notifier.Disconnected(nil)
+		})
+	}()
+	return c
+}
+
+// register sets the notifier that gets the notifications received on the connection, the
+// disconnect included. They are held until release, so that the notifier gets none before
+// it applied the initial rows of its monitors and none is lost meanwhile.
+func (c *ovsdbClient) register(notifier OVNNotifier) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.notifier = notifier
+	c.holding = true
+}
+
+// release passes the held notifications to the notifier in the order they were received,
+// and the following ones as they come
+func (c *ovsdbClient) release() {
+	for {
+		c.mutex.Lock()
+		held, notifier := c.held, c.notifier
+		c.held = nil
+		if len(held) == 0 {
+			c.holding = false
+			c.mutex.Unlock()
+			return
+		}
+		c.mutex.Unlock()
+		for _, f := range held {
+			f(notifier)
+		}
+	}
+}
+
+// notify calls f with the notifier, unless it is held or there is none
+func (c *ovsdbClient) notify(f func(OVNNotifier)) {
+	c.mutex.Lock()
+	if c.holding {
+		c.held = append(c.held, f)
+		c.mutex.Unlock()
+		return
+	}
+	notifier := c.notifier
+	c.mutex.Unlock()
+	if notifier != nil {
+		f(notifier)
+	}
+}
+
+func (c *ovsdbClient) getNotifier() OVNNotifier {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.notifier
+}
+
+// call invokes method and waits for its reply, it returns ctx.Err() if ctx is done first
+func (c *ovsdbClient) call(ctx context.Context, method string, args interface{}, reply interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	call := c.rpc.Go(method, args, reply, make(chan *rpc2.Call, 1))
+	select {
+	case <-call.Done:
+		return call.Error
+	case <-ctx.Done():
+		// the reply is discarded when it arrives
+		return ctx.Err()
+	}
+}
+
+func (c *ovsdbClient) received() {
+	atomic.StoreInt64(&c.lastRecv, time.Now().UnixNano())
+}
+
+// handle marks the connection busy with a notification until the returned func is called.
+// Notifications are handled by the goroutine reading the connection, so the probe does not
+// count the time as inactivity.
+func (c *ovsdbClient) handle() (done func()) {
+	atomic.AddInt32(&c.handling, 1)
+	return func() {
+		c.received()
+		atomic.AddInt32(&c.handling, -1)
+	}
+}
+
+// idle returns the time since traffic was last received
+func (c *ovsdbClient) idle() time.Duration {
+	if atomic.LoadInt32(&c.handling) > 0 {
+		return 0
+	}
+	return time.Since(time.Unix(0, atomic.LoadInt64(&c.lastRecv)))
+}
+
+// probe sends an echo request once nothing is received for interval, and closes the
+// connection if still nothing is received within interval, like the inactivity_probe of
+// ovsdb-server(5). Any traffic counts, so busy connections are not probed.
+func (c *ovsdbClient) probe(interval time.Duration) {
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+	// when the echo request was sent, zero if none is outstanding
+	var sent time.Time
+	for {
+		select {
+		case <-c.rpc.DisconnectNotify():
+			return
+		case <-timer.C:
+		}
+		if !sent.IsZero() {
+			if idle := c.idle(); idle > time.Since(sent) {
+				log.Printf("nothing received for %v, closing the connection\n", idle.Round(time.Millisecond))
+				c.disconnect()
+				return
+			}
+			sent = time.Time{}
+		}
+		if idle := c.idle(); idle < interval {
+			timer.Reset(interval - idle)
+			continue
+		}
+		// the reply is traffic, which is all that is waited for
+		var reply []interface{}
+		sent = time.Now()
+		c.rpc.Go("echo", []interface{}{"echo"}, &reply, make(chan *rpc2.Call, 1))
+		timer.Reset(interval)
+	}
+}
+
+// RFC 7047 section 4.1.11 Echo
+func (c *ovsdbClient) echo(client *rpc2.Client, args []interface{}, reply *[]interface{}) error {
+	*reply = args
+	if notifier := c.getNotifier(); notifier != nil {
+		notifier.Echo(args)
+	}
+	return nil
+}
+
+// RFC 7047 section 4.1.6 Update Notification, params are [<json-value>, <table-updates>]
+func (c *ovsdbClient) update(client *rpc2.Client, params []interface{}, reply *interface{}) error {
+	defer c.handle()()
+	if len(params) < 2 {
+		return errors.New("Invalid Update message")
+	}
+	raw, err := json.Marshal(params[1])
+	if err != nil {
+		return err
+	}
+	tableUpdates, err := unmarshalTableUpdates(raw)
+	if err != nil {
+		return err
+	}
+	c.notify(func(notifier OVNNotifier) {
+		notifier.Update(params[0], tableUpdates)
+	})
+	return nil
+}
+
+// update2 notification of monitor_cond, params are [<json-value>, <table-updates2>]
+func (c *ovsdbClient) update2(client *rpc2.Client, params []interface{}, reply *interface{}) error {
+	defer c.handle()()
+	if len(params) < 2 {
+		return errors.New("Invalid Update2 message")
+	}
+	raw, err := json.Marshal(params[1])
+	if err != nil {
+		return err
+	}
+	var updates tableUpdates2
+	if err := json.Unmarshal(raw, &updates); err != nil {
+		return err
+	}
+	c.notify(func(notifier OVNNotifier) {
+		if notifier, ok := notifier.(update2Notifier); ok {
+			notifier.update2(params[0], updates)
+		}
+	})
+	return nil
+}
+
+// update3 notification of monitor_cond_since, params are [<json-value>, <last-txn-id>, <table-updates2>]
+func (c *ovsdbClient) update3(client *rpc2.Client, params []interface{}, reply *interface{}) error {
+	defer c.handle()()
+	if len(params) < 3 {
+		return errors.New("Invalid Update3 message")
+	}
+	txnID, _ := params[1].(string)
+	raw, err := json.Marshal(params[2])
+	if err != nil {
+		return err
+	}
+	var updates tableUpdates2
+	if err := json.Unmarshal(raw, &updates); err != nil {
+		return err
+	}
+	c.notify(func(notifier OVNNotifier) {
+		if notifier, ok := notifier.(update3Notifier); ok {
+			notifier.update3(params[0], txnID, updates)
+		}
+	})
+	return nil
+}
+
+func unmarshalTableUpdates(data []byte) (libovsdb.TableUpdates, error) {
+	// libovsdb.TableUpdates cannot be unmarshalled directly, see golang issue #6213
+	var raw map[string]map[string]libovsdb.RowUpdate
+	tableUpdates := libovsdb.TableUpdates{Updates: make(map[string]libovsdb.TableUpdate)}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return tableUpdates, err
+	}
+	for table, rows := range raw {
+		tableUpdates.Updates[table] = libovsdb.TableUpdate{Rows: rows}
+	}
+	return tableUpdates, nil
+}
+
+// RFC 7047 section 4.1.1 List Databases
+func (c *ovsdbClient) listDbs(ctx context.Context) ([]string, error) {
+	var dbs []string
+	err := c.call(ctx, "list_dbs", []interface{}{}, &dbs)
+	return dbs, err
+}
+
+// getSchema fetches the schema of db, which is then used to validate transactions on it.
+// RFC 7047 section 4.1.2 Get Schema
+func (c *ovsdbClient) getSchema(ctx context.Context, db string) (*libovsdb.DatabaseSchema, error) {
+	var schema libovsdb.DatabaseSchema
+	if err := c.call(ctx, "get_schema", libovsdb.NewGetSchemaArgs(db), &schema); err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	c.schema[db] = schema
+	c.mutex.Unlock()
+	return &schema, nil
+}
+
+func (c *ovsdbClient) getSchemaCached(db string) (libovsdb.DatabaseSchema, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	schema, ok := c.schema[db]
+	return schema, ok
+}
+
+// RFC 7047 section 4.1.3 Transact
+func (c *ovsdbClient) transact(ctx context.Context, db string, ops ...libovsdb.Operation) ([]libovsdb.OperationResult, error) {
+	schema, ok := c.getSchemaCached(db)
+	if !ok {
+		return nil, errors.New("invalid Database Schema")
+	}
+	if !validateOperations(schema, ops...) {
+		return nil, errors.New("Validation failed for the operation")
+	}
+	args := make([]interface{}, 0, len(ops)+1)
+	args = append(args, db)
+	for _, op := range ops {
+		args = append(args, operation(op))
+	}
+	var reply []libovsdb.OperationResult
+	if err := c.call(ctx, "transact", args, &reply); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
+
+// operation is marshalled with the members RFC 7047 requires even if empty, which
+// libovsdb.Operation leaves out: the where of select, update, mutate, delete and wait,
+// and the rows of wait
+type operation libovsdb.Operation
+
+func (o operation) MarshalJSON() ([]byte, error) {
+	type opAlias libovsdb.Operation
+	v := struct {
+		Where *[]interface{}            `json:"where,omitempty"`
+		Rows  *[]map[string]interface{} `json:"rows,omitempty"`
+		opAlias
+	}{opAlias: opAlias(o)}
+	switch o.Op {
+	case opSelect, opUpdate, opMutate, opDelete, opWait:
+		where := o.Where
+		if where == nil {
+			where = []interface{}{}
+		}
+		v.Where = &where
+	}
+	if o.Op == opWait {
+		rows := o.Rows
+		if rows == nil {
+			rows = []map[string]interface{}{}
+		}
+		v.Rows = &rows
+	}
+	return json.Marshal(&v)
+}
+
+// RFC 7047 section 4.1.5 Monitor
+func (c *ovsdbClient) monitor(ctx context.Context, db string, jsonContext interface{}, requests map[string]libovsdb.MonitorRequest) (*libovsdb.TableUpdates, error) {
+	var raw json.RawMessage
+	if err := c.call(ctx, "monitor", libovsdb.NewMonitorArgs(db, jsonContext, requests), &raw); err != nil {
+		return nil, err
+	}
+	tableUpdates, err := unmarshalTableUpdates(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &tableUpdates, nil
+}
+
+// monitorCond is like monitor, but the rows are selected by the where member of the requests
+// and updates are sent in update2 notifications, see ovsdb-server(7) monitor_cond
+func (c *ovsdbClient) monitorCond(ctx context.Context, db string, jsonContext interface{}, requests map[string]monitorCondRequest) (tableUpdates2, error) {
+	var updates tableUpdates2
+	if err := c.call(ctx, "monitor_cond", []interface{}{db, jsonContext, requests}, &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
+// monitorCondSince is like monitorCond, but if found the updates are only the changes since
+// the transaction lastTxnID. It also returns the id of the last transaction, which update3
+// notifications then carry, see ovsdb-server(7) monitor_cond_since
+func (c *ovsdbClient) monitorCondSince(ctx context.Context, db string, jsonContext interface{}, requests map[string]monitorCondRequest, lastTxnID string) (bool, string, tableUpdates2, error) {
+	var reply []json.RawMessage
+	if err := c.call(ctx, "monitor_cond_since", []interface{}{db, jsonContext, requests, lastTxnID}, &reply); err != nil {
+		return false, "", nil, err
+	}
+	if len(reply) != 3 {
+		return false, "", nil, errors.New("Invalid monitor_cond_since reply")
+	}
+	var found bool
+	var txnID string
+	var updates tableUpdates2
+	for i, v := range []interface{}{&found, &txnID, &updates} {
+		if err := json.Unmarshal(reply[i], v); err != nil {
+			return false, "", nil, err
+		}
+	}
+	return found, txnID, updates, nil
+}
+
+// monitorCondChange replaces the conditions of a monitor_cond, the rows entering or leaving
+// them are notified before the reply, see ovsdb-server(7) monitor_cond_change
+func (c *ovsdbClient) monitorCondChange(ctx context.Context, jsonContext, newJSONContext interface{}, requests map[string]monitorCondRequest) error {
+	var reply interface{}
+	return c.call(ctx, "monitor_cond_change", []interface{}{jsonContext, newJSONContext, requests}, &reply)
+}
+
+// disconnect closes the connection, the notifier gets Disconnected once it is gone
+func (c *ovsdbClient) disconnect() {
+	c.rpc.Close()
+}
+
+// validateOperations checks that ops only refer to tables and columns in schema
+func validateOperations(schema libovsdb.DatabaseSchema, ops ...libovsdb.Operation) bool {
+	for _, op := range ops {
+		table, ok := schema.Tables[op.Table]
+		if !ok {
+			return false
+		}
+		columns := append([]string{}, op.Columns...)
+		for column := range op.Row {
+			columns = append(columns, column)
+		}
+		for _, row := range op.Rows {
+			for column := range row {
+				columns = append(columns, column)
+			}
+		}
+		for _, column := range columns {
+			if _, ok := table.Columns[column]; !ok && column != "_uuid" && column != "_version" {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -16,6 +16,8 @@
 
 package goovn
 
+import "github.com/ebay/libovsdb"
+
 type SBGlobalTableRow struct {
 	UUID        string
 	Options     map[interface{}]interface{}
@@ -23,6 +25,7 @@ type SBGlobalTableRow struct {
 	Connections []string
 	SSL         string
 	IPSec       bool
+	NbCfg       int
 }
 
 func (odbi *ovndb) sbGlobalAddImp(options map[string]string) (*OvnCommand, error) {
@@ -43,3 +46,29 @@ func (odbi *ovndb) sbGlobalSetOptionsImp(options map[string]string) (*OvnCommand
 func (odbi *ovndb) sbGlobalGetOptionsImp() (map[string]string, error) {
 	return odbi.globalGetOptionsImp(TableSBGlobal)
 }
+
+func (odbi *ovndb) rowToSBGlobal(uuid string) *SBGlobalTableRow {
+	cacheGlobal, ok := odbi.cache[TableSBGlobal][uuid]
+	if !ok {
+		return nil
+	}
+	options, _ := cacheGlobal.Fields["options"].(libovsdb.OvsMap)
+	extIDs, _ := cacheGlobal.Fields["external_ids"].(libovsdb.OvsMap)
+	ssl, _ := cacheGlobal.Fields["ssl"].(libovsdb.UUID)
+	ipsec, _ := cacheGlobal.Fields["ipsec"].(bool)
+	global := &SBGlobalTableRow{
+		UUID:       uuid,
+		Options:    options.GoMap,
+		ExternalID: extIDs.GoMap,
+		SSL:        ssl.GoUUID,
+		IPSec:      ipsec,
+	}
+	switch connections := cacheGlobal.Fields["connections"].(type) {
+	case libovsdb.UUID:
+		global.Connections = []string{connections.GoUUID}
+	case libovsdb.OvsSet:
+		global.Connections = odbi.ConvertGoSetToStringArray(connections)
+	}
+	global.NbCfg, _ = cacheGlobal.Fields["nb_cfg"].(int)
+	return global
+}
@@ -0,0 +1,247 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	guuid "github.com/google/uuid"
+)
+
+type tableSchema struct {
+	name    string
+	columns map[string]columnType
+	indexes [][]string
+	isRoot  bool
+	maxRows int
+}
+
+type dbSchema struct {
+	name    string
+	version string
+	tables  map[string]*tableSchema
+	// the schema as sent by get_schema
+	raw json.RawMessage
+}
+
+type row struct {
+	uuid    uuid
+	version uuid
+	columns map[string]datum
+}
+
+// database is an in-memory OVSDB database, rows are never modified in place
+// so that they can be shared by the snapshots of transactions and monitors.
+type database struct {
+	schema *dbSchema
+	tables map[string]map[uuid]*row
+	// the id of the last committed transaction, and the changes of the latest
+	// transactions by id for monitor_cond_since
+	txnID   uuid
+	history []committed
+}
+
+// committed is a transaction committed to a database
+type committed struct {
+	id      uuid
+	changes changeSet
+}
+
+// maxHistory is the number of transactions whose changes are kept for monitor_cond_since
+const maxHistory = 100
+
+func decodeJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func parseSchema(data string) (*dbSchema, error) {
+	var j struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+		Tables  map[string]struct {
+			Columns map[string]struct {
+				Type interface{} `json:"type"`
+			} `json:"columns"`
+			Indexes [][]string `json:"indexes"`
+			IsRoot  bool       `json:"isRoot"`
+			MaxRows int        `json:"maxRows"`
+		} `json:"tables"`
+	}
+	if err := decodeJSON([]byte(data), &j); err != nil {
+		return nil, err
+	}
+	schema := &dbSchema{
+		name:    j.Name,
+		version: j.Version,
+		tables:  make(map[string]*tableSchema),
+	}
+	// normalize the schema sent to clients
+	var raw interface{}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, err
+	}
+	schema.raw, _ = json.Marshal(raw)
+	for name, t := range j.Tables {
+		ts := &tableSchema{
+			name:    name,
+			columns: make(map[string]columnType),
+			indexes: t.Indexes,
+			isRoot:  t.IsRoot,
+			maxRows: t.MaxRows,
+		}
+		for column, c := range t.Columns {
+			ct, err := parseColumnType(c.Type)
+			if err != nil {
+				return nil, fmt.Errorf("column %s of table %s: %v", column, name, err)
+			}
+			ts.columns[column] = ct
+		}
+		schema.tables[name] = ts
+	}
+	for _, ts := range schema.tables {
+		for column, ct := range ts.columns {
+			for _, base := range []*baseType{&ct.key, ct.value} {
+				if base != nil && base.refTable != "" && schema.tables[base.refTable] == nil {
+					return nil, fmt.Errorf("column %s of table %s refers to unknown table %s",
+						column, ts.name, base.refTable)
+				}
+			}
+		}
+	}
+	return schema, nil
+}
+
+// columnType returns the type of column, including the _uuid and _version columns
+func (ts *tableSchema) columnType(column string) (columnType, bool) {
+	switch column {
+	case "_uuid", "_version":
+		return columnType{key: newBaseType(typeUUID), min: 1, max: 1}, true
+	}
+	ct, ok := ts.columns[column]
+	return ct, ok
+}
+
+func newDatabase(schema *dbSchema) *database {
+	db := &database{
+		schema: schema,
+		tables: make(map[string]map[uuid]*row),
+		txnID:  newUUID(),
+	}
+	for name := range schema.tables {
+		db.tables[name] = make(map[uuid]*row)
+	}
+	return db
+}
+
+// commit records the changes of a transaction under a new transaction id
+func (db *database) commit(changes changeSet) {
+	db.txnID = newUUID()
+	db.history = append(db.history, committed{id: db.txnID, changes: changes})
+	if len(db.history) > maxHistory {
+		db.history = db.history[len(db.history)-maxHistory:]
+	}
+}
+
+// changesSince returns the changes committed after the transaction id merged, and whether
+// they are known, which they are not if id is too old or unknown
+func (db *database) changesSince(id uuid) (changeSet, bool) {
+	if id == db.txnID {
+		return changeSet{}, true
+	}
+	for i := len(db.history) - 1; i >= 0; i-- {
+		if db.history[i].id != id {
+			continue
+		}
+		merged := make(changeSet)
+		for _, c := range db.history[i+1:] {
+			for table, rows := range c.changes {
+				if merged[table] == nil {
+					merged[table] = make(map[uuid]rowChange)
+				}
+				for u, change := range rows {
+					if prev, ok := merged[table][u]; ok {
+						change.old = prev.old
+					}
+					merged[table][u] = change
+				}
+			}
+		}
+		for _, rows := range merged {
+			for u, change := range rows {
+				// inserted and deleted meanwhile
+				if change.old == nil && change.new == nil {
+					delete(rows, u)
+				}
+			}
+		}
+		return merged, true
+	}
+	return nil, false
+}
+
+// get returns the value of column, including the _uuid and _version columns
+func (r *row) get(column string) datum {
+	switch column {
+	case "_uuid":
+		return datum{keys: []interface{}{r.uuid}}
+	case "_version":
+		return datum{keys: []interface{}{r.version}}
+	}
+	return r.columns[column]
+}
+
+func (r *row) clone() *row {
+	c := &row{uuid: r.uuid, version: r.version, columns: make(map[string]datum, len(r.columns))}
+	for column, d := range r.columns {
+		c.columns[column] = d
+	}
+	return c
+}
+
+// toJSON returns the given columns of r, all columns but _uuid and _version if columns is nil
+func (r *row) toJSON(ts *tableSchema, columns []string) map[string]interface{} {
+	if columns == nil {
+		columns = make([]string, 0, len(ts.columns))
+		for column := range ts.columns {
+			columns = append(columns, column)
+		}
+	}
+	j := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		ct, _ := ts.columnType(column)
+		j[column] = r.get(column).toJSON(ct)
+	}
+	return j
+}
+
+func newUUID() uuid {
+	return uuid(guuid.New().String())
+}
+
+func sortedColumns(ts *tableSchema) []string {
+	columns := make([]string, 0, len(ts.columns))
+	for column := range ts.columns {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+	return columns
+}
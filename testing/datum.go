@@ -0,0 +1,532 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	typeInteger = "integer"
+	typeReal    = "real"
+	typeBoolean = "boolean"
+	typeString  = "string"
+	typeUUID    = "uuid"
+
+	refStrong = "strong"
+	refWeak   = "weak"
+
+	unlimited = math.MaxInt32
+)
+
+// uuid is the atom of uuid columns, integers are int64, reals float64,
+// booleans bool and strings string.
+type uuid string
+
+// baseType is the type of the keys or values of a column, see RFC 7047 section 3.2
+type baseType struct {
+	atomic     string
+	enum       *datum
+	minInteger int64
+	maxInteger int64
+	minReal    float64
+	maxReal    float64
+	minLength  int
+	maxLength  int
+	refTable   string
+	refType    string
+}
+
+// columnType is the type of a column, an atomic column has min and max 1 and a nil value
+type columnType struct {
+	key   baseType
+	value *baseType
+	min   int
+	max   int
+}
+
+// datum is the value of a column: a sorted set of keys and, for maps, their values
+type datum struct {
+	keys   []interface{}
+	values []interface{}
+}
+
+// ovsdbError is an error reported in the result of a transaction, see RFC 7047 section 3.1
+type ovsdbError struct {
+	Tag     string `json:"error"`
+	Details string `json:"details,omitempty"`
+}
+
+func newError(tag string, format string, args ...interface{}) *ovsdbError {
+	return &ovsdbError{Tag: tag, Details: fmt.Sprintf(format, args...)}
+}
+
+func (e *ovsdbError) Error() string {
+	return e.Tag + ": " + e.Details
+}
+
+func syntaxError(format string, args ...interface{}) *ovsdbError {
+	return newError("syntax error", format, args...)
+}
+
+func constraintViolation(format string, args ...interface{}) *ovsdbError {
+	return newError("constraint violation", format, args...)
+}
+
+// newBaseType returns an atomic type without constraints
+func newBaseType(atomic string) baseType {
+	return baseType{
+		atomic:     atomic,
+		minInteger: math.MinInt64,
+		maxInteger: math.MaxInt64,
+		minReal:    -math.MaxFloat64,
+		maxReal:    math.MaxFloat64,
+		maxLength:  math.MaxInt32,
+	}
+}
+
+func parseBaseType(j interface{}) (baseType, error) {
+	base := newBaseType("")
+	switch t := j.(type) {
+	case string:
+		base.atomic = t
+	case map[string]interface{}:
+		atomic, ok := t["type"].(string)
+		if !ok {
+			return base, fmt.Errorf("invalid base type %v", j)
+		}
+		base.atomic = atomic
+		if enum, ok := t["enum"]; ok {
+			set := columnType{key: newBaseType(atomic), max: unlimited}
+			d, err := set.parseDatum(enum, nil)
+			if err != nil {
+				return base, err
+			}
+			base.enum = &d
+		}
+		if v, ok := t["minInteger"].(json.Number); ok {
+			base.minInteger, _ = v.Int64()
+		}
+		if v, ok := t["maxInteger"].(json.Number); ok {
+			base.maxInteger, _ = v.Int64()
+		}
+		if v, ok := t["minReal"].(json.Number); ok {
+			base.minReal, _ = v.Float64()
+		}
+		if v, ok := t["maxReal"].(json.Number); ok {
+			base.maxReal, _ = v.Float64()
+		}
+		if v, ok := t["minLength"].(json.Number); ok {
+			n, _ := v.Int64()
+			base.minLength = int(n)
+		}
+		if v, ok := t["maxLength"].(json.Number); ok {
+			n, _ := v.Int64()
+			base.maxLength = int(n)
+		}
+		if v, ok := t["refTable"].(string); ok {
+			base.refTable = v
+			base.refType = refStrong
+			if v, ok := t["refType"].(string); ok {
+				base.refType = v
+			}
+		}
+	default:
+		return base, fmt.Errorf("invalid base type %v", j)
+	}
+	switch base.atomic {
+	case typeInteger, typeReal, typeBoolean, typeString, typeUUID:
+		return base, nil
+	}
+	return base, fmt.Errorf("unknown atomic type %q", base.atomic)
+}
+
+func parseColumnType(j interface{}) (columnType, error) {
+	t := columnType{min: 1, max: 1}
+	obj, ok := j.(map[string]interface{})
+	if !ok {
+		key, err := parseBaseType(j)
+		t.key = key
+		return t, err
+	}
+	key, err := parseBaseType(obj["key"])
+	if err != nil {
+		return t, err
+	}
+	t.key = key
+	if v, ok := obj["value"]; ok {
+		value, err := parseBaseType(v)
+		if err != nil {
+			return t, err
+		}
+		t.value = &value
+	}
+	if v, ok := obj["min"].(json.Number); ok {
+		n, _ := v.Int64()
+		t.min = int(n)
+	}
+	switch v := obj["max"].(type) {
+	case json.Number:
+		n, _ := v.Int64()
+		t.max = int(n)
+	case string:
+		if v != "unlimited" {
+			return t, fmt.Errorf("invalid max %q", v)
+		}
+		t.max = unlimited
+	}
+	return t, nil
+}
+
+func (t columnType) isMap() bool {
+	return t.value != nil
+}
+
+// relaxed returns the type with any number of elements, as accepted by mutations and conditions
+func (t columnType) relaxed() columnType {
+	t.min = 0
+	t.max = unlimited
+	return t
+}
+
+// defaultDatum returns the default value of a column, see RFC 7047 section 5.1
+func (t columnType) defaultDatum() datum {
+	var d datum
+	if t.min == 0 {
+		return d
+	}
+	d.keys = []interface{}{t.key.defaultAtom()}
+	if t.isMap() {
+		d.values = []interface{}{t.value.defaultAtom()}
+	}
+	return d
+}
+
+func (b baseType) defaultAtom() interface{} {
+	switch b.atomic {
+	case typeInteger:
+		return int64(0)
+	case typeReal:
+		return float64(0)
+	case typeBoolean:
+		return false
+	case typeUUID:
+		return uuid("00000000-0000-0000-0000-000000000000")
+	}
+	return ""
+}
+
+// symbols resolves named-uuids of a transaction
+type symbols interface {
+	resolve(name string) uuid
+}
+
+// parseDatum parses a value in OVSDB notation, see RFC 7047 section 5.1
+func (t columnType) parseDatum(j interface{}, syms symbols) (datum, error) {
+	var d datum
+	if t.isMap() {
+		array, ok := j.([]interface{})
+		if !ok || len(array) != 2 || array[0] != "map" {
+			return d, syntaxError("expected map, got %v", j)
+		}
+		pairs, ok := array[1].([]interface{})
+		if !ok {
+			return d, syntaxError("expected map, got %v", j)
+		}
+		for _, p := range pairs {
+			pair, ok := p.([]interface{})
+			if !ok || len(pair) != 2 {
+				return d, syntaxError("expected key-value pair, got %v", p)
+			}
+			key, err := t.key.parseAtom(pair[0], syms)
+			if err != nil {
+				return d, err
+			}
+			value, err := t.value.parseAtom(pair[1], syms)
+			if err != nil {
+				return d, err
+			}
+			d.keys = append(d.keys, key)
+			d.values = append(d.values, value)
+		}
+	} else if array, ok := j.([]interface{}); ok && len(array) == 2 && array[0] == "set" {
+		elements, ok := array[1].([]interface{})
+		if !ok {
+			return d, syntaxError("expected set, got %v", j)
+		}
+		for _, e := range elements {
+			key, err := t.key.parseAtom(e, syms)
+			if err != nil {
+				return d, err
+			}
+			d.keys = append(d.keys, key)
+		}
+	} else {
+		key, err := t.key.parseAtom(j, syms)
+		if err != nil {
+			return d, err
+		}
+		d.keys = []interface{}{key}
+	}
+	d.sort()
+	if len(d.keys) < t.min || len(d.keys) > t.max {
+		return d, constraintViolation("%d elements where %d to %d are allowed", len(d.keys), t.min, t.max)
+	}
+	return d, nil
+}
+
+func (b baseType) parseAtom(j interface{}, syms symbols) (interface{}, error) {
+	var atom interface{}
+	switch b.atomic {
+	case typeInteger:
+		n, ok := j.(json.Number)
+		if !ok {
+			return nil, syntaxError("expected integer, got %v", j)
+		}
+		v, err := n.Int64()
+		if err != nil {
+			return nil, syntaxError("expected integer, got %v", j)
+		}
+		if v < b.minInteger || v > b.maxInteger {
+			return nil, constraintViolation("%d is not in range %d to %d", v, b.minInteger, b.maxInteger)
+		}
+		atom = v
+	case typeReal:
+		n, ok := j.(json.Number)
+		if !ok {
+			return nil, syntaxError("expected real, got %v", j)
+		}
+		v, err := n.Float64()
+		if err != nil {
+			return nil, syntaxError("expected real, got %v", j)
+		}
+		if v < b.minReal || v > b.maxReal {
+			return nil, constraintViolation("%g is not in range %g to %g", v, b.minReal, b.maxReal)
+		}
+		atom = v
+	case typeBoolean:
+		v, ok := j.(bool)
+		if !ok {
+			return nil, syntaxError("expected boolean, got %v", j)
+		}
+		atom = v
+	case typeString:
+		v, ok := j.(string)
+		if !ok {
+			return nil, syntaxError("expected string, got %v", j)
+		}
+		if len(v) < b.minLength || len(v) > b.maxLength {
+			return nil, constraintViolation("length of %q is not in range %d to %d", v, b.minLength, b.maxLength)
+		}
+		atom = v
+	case typeUUID:
+		array, ok := j.([]interface{})
+		if !ok || len(array) != 2 {
+			return nil, syntaxError("expected uuid, got %v", j)
+		}
+		name, ok := array[1].(string)
+		if !ok {
+			return nil, syntaxError("expected uuid, got %v", j)
+		}
+		switch {
+		case array[0] == "uuid":
+			atom = uuid(name)
+		case array[0] == "named-uuid" && syms != nil:
+			atom = syms.resolve(name)
+		default:
+			return nil, syntaxError("expected uuid, got %v", j)
+		}
+	}
+	if b.enum != nil && !b.enum.contains(atom) {
+		return nil, constraintViolation("%v is not one of the allowed values", atom)
+	}
+	return atom, nil
+}
+
+// toJSON returns d in OVSDB notation, a set of exactly one element is an atom
+func (d datum) toJSON(t columnType) interface{} {
+	if t.isMap() {
+		pairs := make([]interface{}, 0, len(d.keys))
+		for i := range d.keys {
+			pairs = append(pairs, []interface{}{atomToJSON(d.keys[i]), atomToJSON(d.values[i])})
+		}
+		return []interface{}{"map", pairs}
+	}
+	if len(d.keys) == 1 {
+		return atomToJSON(d.keys[0])
+	}
+	elements := make([]interface{}, 0, len(d.keys))
+	for _, key := range d.keys {
+		elements = append(elements, atomToJSON(key))
+	}
+	return []interface{}{"set", elements}
+}
+
+func atomToJSON(atom interface{}) interface{} {
+	if u, ok := atom.(uuid); ok {
+		return []interface{}{"uuid", string(u)}
+	}
+	return atom
+}
+
+func compareAtoms(a, b interface{}) int {
+	switch x := a.(type) {
+	case int64:
+		y := b.(int64)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	case float64:
+		y := b.(float64)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	case bool:
+		y := b.(bool)
+		if x != y {
+			if !x {
+				return -1
+			}
+			return 1
+		}
+	case string:
+		y := b.(string)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	case uuid:
+		y := b.(uuid)
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	}
+	return 0
+}
+
+// sort sorts the keys, keeping the first value of duplicate keys
+func (d *datum) sort() {
+	idx := make([]int, len(d.keys))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return compareAtoms(d.keys[idx[i]], d.keys[idx[j]]) < 0
+	})
+	var keys, values []interface{}
+	for _, i := range idx {
+		if len(keys) > 0 && compareAtoms(keys[len(keys)-1], d.keys[i]) == 0 {
+			continue
+		}
+		keys = append(keys, d.keys[i])
+		if d.values != nil {
+			values = append(values, d.values[i])
+		}
+	}
+	d.keys, d.values = keys, values
+}
+
+func (d datum) find(key interface{}) int {
+	i := sort.Search(len(d.keys), func(i int) bool {
+		return compareAtoms(d.keys[i], key) >= 0
+	})
+	if i < len(d.keys) && compareAtoms(d.keys[i], key) == 0 {
+		return i
+	}
+	return -1
+}
+
+func (d datum) contains(key interface{}) bool {
+	return d.find(key) >= 0
+}
+
+func (d datum) equal(o datum) bool {
+	return len(d.keys) == len(o.keys) && d.includes(o)
+}
+
+// includes reports whether every element of o is in d, for maps both key and value
+func (d datum) includes(o datum) bool {
+	for i, key := range o.keys {
+		j := d.find(key)
+		if j < 0 {
+			return false
+		}
+		if o.values != nil && compareAtoms(d.values[j], o.values[i]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// excludes reports whether no element of o is in d, for maps both key and value
+func (d datum) excludes(o datum) bool {
+	for i, key := range o.keys {
+		j := d.find(key)
+		if j < 0 {
+			continue
+		}
+		if o.values == nil || compareAtoms(d.values[j], o.values[i]) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// union returns d plus the elements of o whose keys are not in d
+func (d datum) union(o datum) datum {
+	r := datum{keys: append([]interface{}{}, d.keys...)}
+	if d.values != nil || o.values != nil {
+		r.values = append([]interface{}{}, d.values...)
+	}
+	for i, key := range o.keys {
+		if !d.contains(key) {
+			r.keys = append(r.keys, key)
+			if o.values != nil {
+				r.values = append(r.values, o.values[i])
+			}
+		}
+	}
+	r.sort()
+	return r
+}
+
+// difference returns d without the elements of o, without the keys of o if o is a set
+func (d datum) difference(o datum) datum {
+	var r datum
+	for i, key := range d.keys {
+		j := o.find(key)
+		remove := j >= 0 && (o.values == nil || compareAtoms(o.values[j], d.values[i]) == 0)
+		if remove {
+			continue
+		}
+		r.keys = append(r.keys, key)
+		if d.values != nil {
+			r.values = append(r.values, d.values[i])
+		}
+	}
+	return r
+}
@@ -0,0 +1,352 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"fmt"
+)
+
+// monitorRequest selects the columns and kinds of changes of a table, see RFC 7047 section 4.1.5,
+// and for monitor_cond the rows, see ovsdb-server(7)
+type monitorRequest struct {
+	columns []string
+	where   condition
+	initial bool
+	insert  bool
+	delete  bool
+	modify  bool
+}
+
+// condition selects the rows of a monitor_cond request: those matching any of its clauses,
+// all rows if there are none
+type condition struct {
+	clauses []clause
+	all     bool
+}
+
+type monitor struct {
+	id       interface{}
+	db       *database
+	requests map[string][]monitorRequest
+	// whether updates are sent in the update2 format of monitor_cond
+	cond bool
+	// whether updates are sent in update3 notifications of monitor_cond_since
+	since bool
+}
+
+func newMonitor(db *database, id interface{}, j interface{}, cond bool) (*monitor, error) {
+	tables, ok := j.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected monitor requests, got %v", j)
+	}
+	m := &monitor{id: id, db: db, requests: make(map[string][]monitorRequest), cond: cond}
+	for table, r := range tables {
+		ts, ok := db.schema.tables[table]
+		if !ok {
+			return nil, fmt.Errorf("unknown table %s", table)
+		}
+		requests, ok := r.([]interface{})
+		if !ok {
+			requests = []interface{}{r}
+		}
+		for _, request := range requests {
+			mr, err := parseMonitorRequest(ts, request, cond)
+			if err != nil {
+				return nil, err
+			}
+			m.requests[table] = append(m.requests[table], mr)
+		}
+	}
+	return m, nil
+}
+
+func parseMonitorRequest(ts *tableSchema, j interface{}, cond bool) (monitorRequest, error) {
+	mr := monitorRequest{where: condition{all: true}, initial: true, insert: true, delete: true, modify: true}
+	request, ok := j.(map[string]interface{})
+	if !ok {
+		return mr, fmt.Errorf("expected monitor request, got %v", j)
+	}
+	if where, ok := request["where"]; ok && cond {
+		c, err := parseCondition(ts, where)
+		if err != nil {
+			return mr, err
+		}
+		mr.where = c
+	}
+	if columns, ok := request["columns"]; ok {
+		parsed, err := parseColumns(ts, columns)
+		if err != nil {
+			return mr, err
+		}
+		mr.columns = parsed
+	}
+	if len(mr.columns) == 0 {
+		mr.columns = sortedColumns(ts)
+	}
+	if sel, ok := request["select"].(map[string]interface{}); ok {
+		for name, flag := range map[string]*bool{
+			"initial": &mr.initial, "insert": &mr.insert, "delete": &mr.delete, "modify": &mr.modify} {
+			if v, ok := sel[name].(bool); ok {
+				*flag = v
+			}
+		}
+	}
+	return mr, nil
+}
+
+func parseCondition(ts *tableSchema, j interface{}) (condition, error) {
+	conditions, ok := j.([]interface{})
+	if !ok {
+		return condition{}, fmt.Errorf("expected conditions, got %v", j)
+	}
+	c := condition{all: len(conditions) == 0}
+	for _, e := range conditions {
+		if b, ok := e.(bool); ok {
+			c.all = c.all || b
+			continue
+		}
+		cl, err := parseClause(ts, e, nil)
+		if err != nil {
+			return condition{}, err
+		}
+		c.clauses = append(c.clauses, cl)
+	}
+	return c, nil
+}
+
+func (c condition) matches(r *row) bool {
+	if c.all {
+		return true
+	}
+	for _, cl := range c.clauses {
+		if cl.matches(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// notification returns the notification of updates for the monitor
+func (m *monitor) notification(updates map[string]interface{}) notification {
+	switch {
+	case m.since:
+		return notification{Method: "update3", Params: []interface{}{m.id, m.db.txnID, updates}}
+	case m.cond:
+		return notification{Method: "update2", Params: []interface{}{m.id, updates}}
+	}
+	return notification{Method: "update", Params: []interface{}{m.id, updates}}
+}
+
+// initial returns the table-updates with the current rows of the monitored tables
+func (m *monitor) initial() map[string]interface{} {
+	updates := make(map[string]interface{})
+	for table, requests := range m.requests {
+		ts := m.db.schema.tables[table]
+		rows := make(map[string]interface{})
+		for u, r := range m.db.tables[table] {
+			var columns []string
+			for _, mr := range requests {
+				if mr.initial && mr.where.matches(r) {
+					columns = append(columns, mr.columns...)
+				}
+			}
+			if columns == nil {
+				continue
+			}
+			if m.cond {
+				rows[string(u)] = map[string]interface{}{"initial": nonDefaultJSON(ts, r, columns)}
+			} else {
+				rows[string(u)] = map[string]interface{}{"new": r.toJSON(ts, columns)}
+			}
+		}
+		if len(rows) > 0 {
+			updates[table] = rows
+		}
+	}
+	return updates
+}
+
+// updates returns the table-updates, or table-updates2 for monitor_cond, for changes,
+// nil if none of them are monitored
+func (m *monitor) updates(changes changeSet) map[string]interface{} {
+	var updates map[string]interface{}
+	for table, requests := range m.requests {
+		ts := m.db.schema.tables[table]
+		rows := make(map[string]interface{})
+		for u, change := range changes[table] {
+			update := make(map[string]interface{})
+			for _, mr := range requests {
+				// rows entering or leaving the condition are inserted or deleted
+				was := change.old != nil && mr.where.matches(change.old)
+				is := change.new != nil && mr.where.matches(change.new)
+				switch {
+				case !was && is && mr.insert:
+					if m.cond {
+						mergeColumns(update, "insert", nonDefaultJSON(ts, change.new, mr.columns))
+					} else {
+						mergeColumns(update, "new", change.new.toJSON(ts, mr.columns))
+					}
+				case was && !is && mr.delete:
+					if m.cond {
+						update["delete"] = nil
+					} else {
+						mergeColumns(update, "old", change.old.toJSON(ts, mr.columns))
+					}
+				case was && is && mr.modify:
+					var modified []string
+					for _, column := range mr.columns {
+						if !change.old.get(column).equal(change.new.get(column)) {
+							modified = append(modified, column)
+						}
+					}
+					if len(modified) == 0 {
+						break
+					}
+					if m.cond {
+						mergeColumns(update, "modify", diffJSON(ts, change.old, change.new, modified))
+					} else {
+						mergeColumns(update, "old", change.old.toJSON(ts, modified))
+						mergeColumns(update, "new", change.new.toJSON(ts, mr.columns))
+					}
+				}
+			}
+			if len(update) > 0 {
+				rows[string(u)] = update
+			}
+		}
+		if len(rows) > 0 {
+			if updates == nil {
+				updates = make(map[string]interface{})
+			}
+			updates[table] = rows
+		}
+	}
+	return updates
+}
+
+// changeConditions replaces the conditions of a monitor_cond and returns the table-updates2
+// inserting and deleting the rows that enter or leave them, nil if there are none
+func (m *monitor) changeConditions(j interface{}) (map[string]interface{}, error) {
+	tables, ok := j.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected monitor condition change requests, got %v", j)
+	}
+	var updates map[string]interface{}
+	for table, r := range tables {
+		requests, ok := m.requests[table]
+		if !ok {
+			return nil, fmt.Errorf("table %s is not monitored", table)
+		}
+		ts := m.db.schema.tables[table]
+		changes, ok := r.([]interface{})
+		if !ok {
+			changes = []interface{}{r}
+		}
+		for _, change := range changes {
+			request, ok := change.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected monitor condition change request, got %v", change)
+			}
+			where, ok := request["where"]
+			if !ok {
+				continue
+			}
+			c, err := parseCondition(ts, where)
+			if err != nil {
+				return nil, err
+			}
+			rows := make(map[string]interface{})
+			for i, mr := range requests {
+				for u, r := range m.db.tables[table] {
+					was, is := mr.where.matches(r), c.matches(r)
+					if !was && is {
+						rows[string(u)] = map[string]interface{}{"insert": nonDefaultJSON(ts, r, mr.columns)}
+					} else if was && !is {
+						rows[string(u)] = map[string]interface{}{"delete": nil}
+					}
+				}
+				requests[i].where = c
+			}
+			if len(rows) > 0 {
+				if updates == nil {
+					updates = make(map[string]interface{})
+				}
+				updates[table] = rows
+			}
+		}
+	}
+	return updates, nil
+}
+
+// nonDefaultJSON returns the given columns of r that do not have their default value,
+// as rows are sent in update2 notifications
+func nonDefaultJSON(ts *tableSchema, r *row, columns []string) map[string]interface{} {
+	j := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		ct, _ := ts.columnType(column)
+		if d := r.get(column); !d.equal(ct.defaultDatum()) {
+			j[column] = d.toJSON(ct)
+		}
+	}
+	return j
+}
+
+// diffJSON returns the <row-update2> modify member for the columns changed from before to after:
+// the new value of scalars, the elements added or removed for sets, and for maps the pairs
+// added or removed and the new values of keys that are updated, see ovsdb-server(7)
+func diffJSON(ts *tableSchema, before, after *row, columns []string) map[string]interface{} {
+	j := make(map[string]interface{}, len(columns))
+	for _, column := range columns {
+		ct, _ := ts.columnType(column)
+		o, n := before.get(column), after.get(column)
+		switch {
+		case ct.isMap():
+			var d datum
+			for i, key := range o.keys {
+				if !n.contains(key) {
+					d.keys = append(d.keys, key)
+					d.values = append(d.values, o.values[i])
+				}
+			}
+			for i, key := range n.keys {
+				if k := o.find(key); k < 0 || compareAtoms(o.values[k], n.values[i]) != 0 {
+					d.keys = append(d.keys, key)
+					d.values = append(d.values, n.values[i])
+				}
+			}
+			d.sort()
+			j[column] = d.toJSON(ct)
+		case ct.min == 1 && ct.max == 1:
+			j[column] = n.toJSON(ct)
+		default:
+			d := o.difference(n).union(n.difference(o))
+			j[column] = d.toJSON(ct)
+		}
+	}
+	return j
+}
+
+func mergeColumns(update map[string]interface{}, key string, columns map[string]interface{}) {
+	merged, ok := update[key].(map[string]interface{})
+	if !ok {
+		update[key] = columns
+		return
+	}
+	for column, value := range columns {
+		merged[column] = value
+	}
+}
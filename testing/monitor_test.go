@@ -0,0 +1,166 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeTestJSON(t *testing.T, s string) interface{} {
+	var j interface{}
+	if err := decodeJSON([]byte(s), &j); err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+// transactChanges runs a transaction given in JSON and returns the changes committed
+func transactChanges(t *testing.T, db *database, ops string) changeSet {
+	results, changes := db.transact(decodeTestJSON(t, ops).([]interface{}))
+	if err := lastError(toJSON(t, results)); err != "" {
+		t.Fatal(err)
+	}
+	return changes
+}
+
+func toJSON(t *testing.T, v interface{}) []map[string]interface{} {
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var j []map[string]interface{}
+	if err = json.Unmarshal(b, &j); err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+// rowUpdates returns the row updates of table in JSON, keyed by the name column
+func rowUpdates(t *testing.T, db *database, updates map[string]interface{}, table string) map[string]interface{} {
+	b, err := json.Marshal(updates[table])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rows map[string]interface{}
+	if err = json.Unmarshal(b, &rows); err != nil {
+		t.Fatal(err)
+	}
+	named := make(map[string]interface{})
+	for u, update := range rows {
+		if r, ok := db.tables[table][uuid(u)]; ok {
+			named[r.get("name").keys[0].(string)] = update
+		} else {
+			named[u] = update
+		}
+	}
+	return named
+}
+
+func TestMonitorCond(t *testing.T) {
+	db := newTestDatabase(t)
+	transactChanges(t, db, `[
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls1",
+			"external_ids": ["map", [["node", "a"]]]}},
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls2",
+			"external_ids": ["map", [["node", "b"]]]}}]`)
+
+	m, err := newMonitor(db, "id", decodeTestJSON(t, `{"Logical_Switch": {
+		"columns": ["name", "external_ids", "other_config"],
+		"where": [["external_ids", "includes", ["map", [["node", "a"]]]]]}}`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// default values are left out
+	assert.Equal(t, map[string]interface{}{
+		"ls1": map[string]interface{}{"initial": map[string]interface{}{
+			"name": "ls1", "external_ids": []interface{}{"map", []interface{}{[]interface{}{"node", "a"}}}}},
+	}, rowUpdates(t, db, m.initial(), "Logical_Switch"))
+
+	// maps are sent as the pairs changed, rows leaving the condition are deleted
+	changes := transactChanges(t, db, `[
+		{"op": "mutate", "table": "Logical_Switch", "where": [["name", "==", "ls1"]],
+			"mutations": [["external_ids", "insert", ["map", [["k", "v"]]]]]},
+		{"op": "update", "table": "Logical_Switch", "where": [["name", "==", "ls2"]],
+			"row": {"external_ids": ["map", [["node", "a"]]]}}]`)
+	assert.Equal(t, map[string]interface{}{
+		"ls1": map[string]interface{}{"modify": map[string]interface{}{
+			"external_ids": []interface{}{"map", []interface{}{[]interface{}{"k", "v"}}}}},
+		"ls2": map[string]interface{}{"insert": map[string]interface{}{
+			"name": "ls2", "external_ids": []interface{}{"map", []interface{}{[]interface{}{"node", "a"}}}}},
+	}, rowUpdates(t, db, m.updates(changes), "Logical_Switch"))
+
+	updates, err := m.changeConditions(decodeTestJSON(t, `{"Logical_Switch": [{"where": [["name", "==", "ls2"]]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"ls1": map[string]interface{}{"delete": nil},
+	}, rowUpdates(t, db, updates, "Logical_Switch"))
+
+	changes = transactChanges(t, db, `[
+		{"op": "update", "table": "Logical_Switch", "where": [["name", "==", "ls1"]],
+			"row": {"other_config": ["map", [["a", "b"]]]}}]`)
+	assert.Nil(t, m.updates(changes))
+}
+
+func TestChangesSince(t *testing.T) {
+	db := newTestDatabase(t)
+	transactChanges(t, db, `[
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls1"}},
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls2"}}]`)
+	id := db.txnID
+
+	transactChanges(t, db, `[
+		{"op": "update", "table": "Logical_Switch", "where": [["name", "==", "ls1"]],
+			"row": {"external_ids": ["map", [["k", "v"]]]}},
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls3"}}]`)
+	transactChanges(t, db, `[
+		{"op": "delete", "table": "Logical_Switch", "where": [["name", "==", "ls2"]]},
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls4"}}]`)
+	transactChanges(t, db, `[
+		{"op": "delete", "table": "Logical_Switch", "where": [["name", "==", "ls4"]]}]`)
+	assert.NotEqual(t, id, db.txnID)
+
+	m, err := newMonitor(db, "id", decodeTestJSON(t, `{"Logical_Switch": {"columns": ["name", "external_ids"]}}`), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// rows inserted and deleted meanwhile are left out
+	changes, ok := db.changesSince(id)
+	assert.True(t, ok)
+	updates := rowUpdates(t, db, m.updates(changes), "Logical_Switch")
+	assert.Equal(t, 3, len(updates))
+	assert.Equal(t, map[string]interface{}{"modify": map[string]interface{}{
+		"external_ids": []interface{}{"map", []interface{}{[]interface{}{"k", "v"}}}}}, updates["ls1"])
+	assert.Equal(t, map[string]interface{}{"insert": map[string]interface{}{"name": "ls3"}}, updates["ls3"])
+	deleted := 0
+	for _, update := range updates {
+		if _, ok := update.(map[string]interface{})["delete"]; ok {
+			deleted++
+		}
+	}
+	assert.Equal(t, 1, deleted)
+
+	changes, ok = db.changesSince(db.txnID)
+	assert.True(t, ok)
+	assert.Nil(t, m.updates(changes))
+	_, ok = db.changesSince(newUUID())
+	assert.False(t, ok)
+}
@@ -0,0 +1,467 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+// Package testing provides an in-memory OVSDB server, so that go-ovn clients can be
+// tested without a running ovsdb-server:
+//
+//	srv, _ := testing.NewServer(testing.NBSchema, testing.SBSchema)
+//	defer srv.Close()
+//	addr, _ := srv.Listen("unix", "/tmp/ovnnb_db.sock")
+//	client, _ := goovn.NewClient(&goovn.Config{Db: goovn.DBNB, Addr: addr})
+//
+// It implements the list_dbs, get_schema, transact, monitor, monitor_cancel and echo
+// methods of RFC 7047 with update notifications, enforcing the column types, references
+// and indexes of the schemas, as well as monitor_cond and monitor_cond_change with
+// update2 notifications and monitor_cond_since with update3 notifications.
+package testing
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+)
+
+// Server is an in-memory OVSDB server
+type Server struct {
+	// protects all fields and the databases, so that transactions are serialized
+	mutex     sync.Mutex
+	dbs       map[string]*database
+	listeners []net.Listener
+	conns     map[*conn]bool
+	closed    bool
+	disabled  map[string]bool
+	// closed once the server answers requests again, nil unless frozen
+	frozen chan struct{}
+}
+
+// NewServer returns a server with an empty database for each of the given schemas,
+// e.g. NBSchema and SBSchema, and the _Server database, see SetClusterState
+func NewServer(schemas ...string) (*Server, error) {
+	srv := &Server{
+		dbs:      make(map[string]*database),
+		conns:    make(map[*conn]bool),
+		disabled: make(map[string]bool),
+	}
+	for _, s := range schemas {
+		schema, err := parseSchema(s)
+		if err != nil {
+			return nil, err
+		}
+		srv.dbs[schema.name] = newDatabase(schema)
+	}
+	if err := srv.addServerDatabase(); err != nil {
+		return nil, err
+	}
+	return srv, nil
+}
+
+// Listen accepts connections on the given address, e.g. "unix" and a socket path, and
+// returns the address in ovsdb connection method format, e.g. "unix:PATH"
+func (srv *Server) Listen(network, address string) (string, error) {
+	l, err := net.Listen(network, address)
+	if err != nil {
+		return "", err
+	}
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	if srv.closed {
+		l.Close()
+		return "", errors.New("server closed")
+	}
+	srv.listeners = append(srv.listeners, l)
+	go srv.serve(l)
+	return network + ":" + l.Addr().String(), nil
+}
+
+func (srv *Server) serve(l net.Listener) {
+	for {
+		nc, err := l.Accept()
+		if err != nil {
+			return
+		}
+		c := newConn(srv, nc)
+		srv.mutex.Lock()
+		if srv.closed {
+			srv.mutex.Unlock()
+			nc.Close()
+			return
+		}
+		srv.conns[c] = true
+		srv.mutex.Unlock()
+		go c.run()
+	}
+}
+
+// Close stops listening and closes all connections
+func (srv *Server) Close() error {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	srv.closed = true
+	for _, l := range srv.listeners {
+		l.Close()
+	}
+	for c := range srv.conns {
+		c.close()
+	}
+	return nil
+}
+
+// Disconnect closes all connections, clients may connect again to the same databases
+func (srv *Server) Disconnect() {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	for c := range srv.conns {
+		c.close()
+	}
+}
+
+// Disable makes the server answer methods as unknown, like older versions of ovsdb-server
+func (srv *Server) Disable(methods ...string) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	for _, method := range methods {
+		srv.disabled[method] = true
+	}
+}
+
+// Freeze stops answering requests until the returned function is called, like a server
+// behind a half-open connection
+func (srv *Server) Freeze() (thaw func()) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	frozen := make(chan struct{})
+	srv.frozen = frozen
+	var once sync.Once
+	return func() {
+		srv.mutex.Lock()
+		defer srv.mutex.Unlock()
+		if srv.frozen == frozen {
+			srv.frozen = nil
+		}
+		once.Do(func() { close(frozen) })
+	}
+}
+
+// message is a JSON-RPC request, notification or response, see RFC 7047 section 4
+type message struct {
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	ID     json.RawMessage `json:"id"`
+	Result interface{}     `json:"result,omitempty"`
+	Error  interface{}     `json:"error,omitempty"`
+}
+
+type response struct {
+	ID     json.RawMessage `json:"id"`
+	Result interface{}     `json:"result"`
+	Error  interface{}     `json:"error"`
+}
+
+type notification struct {
+	ID     interface{}   `json:"id"`
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// conn is a client connection, messages are sent in order by a separate goroutine
+// so that notifications can be queued while holding the server mutex
+type conn struct {
+	srv      *Server
+	nc       net.Conn
+	monitors map[string]*monitor
+	mutex    sync.Mutex
+	cond     *sync.Cond
+	queue    []interface{}
+	closed   bool
+}
+
+func newConn(srv *Server, nc net.Conn) *conn {
+	c := &conn{srv: srv, nc: nc, monitors: make(map[string]*monitor)}
+	c.cond = sync.NewCond(&c.mutex)
+	return c
+}
+
+func (c *conn) send(msg interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.queue = append(c.queue, msg)
+	c.cond.Signal()
+}
+
+func (c *conn) close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if !c.closed {
+		c.closed = true
+		c.nc.Close()
+		c.cond.Signal()
+	}
+}
+
+func (c *conn) write() {
+	enc := json.NewEncoder(c.nc)
+	for {
+		c.mutex.Lock()
+		for len(c.queue) == 0 && !c.closed {
+			c.cond.Wait()
+		}
+		if c.closed {
+			c.mutex.Unlock()
+			return
+		}
+		msg := c.queue[0]
+		c.queue = c.queue[1:]
+		c.mutex.Unlock()
+		if err := enc.Encode(msg); err != nil {
+			c.close()
+			return
+		}
+	}
+}
+
+func (c *conn) run() {
+	go c.write()
+	defer func() {
+		c.close()
+		c.srv.mutex.Lock()
+		delete(c.srv.conns, c)
+		c.srv.mutex.Unlock()
+	}()
+	dec := json.NewDecoder(c.nc)
+	dec.UseNumber()
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			return
+		}
+		if msg.Method == "" {
+			// a response to a request of the server, none are sent
+			continue
+		}
+		c.srv.mutex.Lock()
+		frozen := c.srv.frozen
+		c.srv.mutex.Unlock()
+		if frozen != nil {
+			<-frozen
+		}
+		result, err := c.handle(msg.Method, msg.Params)
+		if len(msg.ID) == 0 || bytes.Equal(msg.ID, []byte("null")) {
+			// notification
+			continue
+		}
+		if err != nil {
+			c.send(response{ID: msg.ID, Error: err.Error()})
+		} else {
+			c.send(response{ID: msg.ID, Result: result})
+		}
+	}
+}
+
+func (c *conn) handle(method string, rawParams json.RawMessage) (interface{}, error) {
+	var params []interface{}
+	if len(rawParams) > 0 {
+		if err := decodeJSON(rawParams, &params); err != nil {
+			return nil, err
+		}
+	}
+	c.srv.mutex.Lock()
+	disabled := c.srv.disabled[method]
+	c.srv.mutex.Unlock()
+	if disabled {
+		return nil, errors.New("unknown method")
+	}
+	switch method {
+	case "echo":
+		if params == nil {
+			params = []interface{}{}
+		}
+		return params, nil
+	case "list_dbs":
+		c.srv.mutex.Lock()
+		defer c.srv.mutex.Unlock()
+		dbs := make([]string, 0, len(c.srv.dbs))
+		for name := range c.srv.dbs {
+			dbs = append(dbs, name)
+		}
+		sort.Strings(dbs)
+		return dbs, nil
+	case "get_schema":
+		db, err := c.database(params)
+		if err != nil {
+			return nil, err
+		}
+		return db.schema.raw, nil
+	case "transact":
+		db, err := c.database(params)
+		if err != nil {
+			return nil, err
+		}
+		return c.srv.transact(db, params[1:]), nil
+	case "monitor", "monitor_cond":
+		db, err := c.database(params)
+		if err != nil {
+			return nil, err
+		}
+		if len(params) != 3 {
+			return nil, fmt.Errorf("syntax error: %s takes 3 parameters", method)
+		}
+		return c.monitor(db, params[1], params[2], method == "monitor_cond")
+	case "monitor_cond_since":
+		db, err := c.database(params)
+		if err != nil {
+			return nil, err
+		}
+		if len(params) != 4 {
+			return nil, errors.New("syntax error: monitor_cond_since takes 4 parameters")
+		}
+		return c.monitorSince(db, params[1], params[2], params[3])
+	case "monitor_cond_change":
+		if len(params) != 3 {
+			return nil, errors.New("syntax error: monitor_cond_change takes 3 parameters")
+		}
+		return c.monitorCondChange(params[0], params[1], params[2])
+	case "monitor_cancel":
+		if len(params) != 1 {
+			return nil, errors.New("syntax error: monitor_cancel takes 1 parameter")
+		}
+		c.srv.mutex.Lock()
+		defer c.srv.mutex.Unlock()
+		key := monitorKey(params[0])
+		if _, ok := c.monitors[key]; !ok {
+			return nil, errors.New("unknown monitor")
+		}
+		delete(c.monitors, key)
+		return map[string]interface{}{}, nil
+	}
+	return nil, errors.New("unknown method")
+}
+
+// database returns the database named by the first parameter
+func (c *conn) database(params []interface{}) (*database, error) {
+	if len(params) == 0 {
+		return nil, errors.New("syntax error: missing database name")
+	}
+	name, _ := params[0].(string)
+	c.srv.mutex.Lock()
+	defer c.srv.mutex.Unlock()
+	db, ok := c.srv.dbs[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown database %q", name)
+	}
+	return db, nil
+}
+
+func monitorKey(id interface{}) string {
+	b, _ := json.Marshal(id)
+	return string(b)
+}
+
+func (c *conn) monitor(db *database, id interface{}, requests interface{}, cond bool) (interface{}, error) {
+	c.srv.mutex.Lock()
+	defer c.srv.mutex.Unlock()
+	key := monitorKey(id)
+	if _, ok := c.monitors[key]; ok {
+		return nil, errors.New("duplicate monitor ID")
+	}
+	m, err := newMonitor(db, id, requests, cond)
+	if err != nil {
+		return nil, err
+	}
+	c.monitors[key] = m
+	return m.initial(), nil
+}
+
+// monitorSince is monitor_cond replying [found, last-txn-id, table-updates2] with only the
+// changes since the transaction lastID if they are known, all rows otherwise. Notifications
+// are update3 with the id of the transaction, see ovsdb-server(7) monitor_cond_since.
+func (c *conn) monitorSince(db *database, id interface{}, requests interface{}, lastID interface{}) (interface{}, error) {
+	c.srv.mutex.Lock()
+	defer c.srv.mutex.Unlock()
+	key := monitorKey(id)
+	if _, ok := c.monitors[key]; ok {
+		return nil, errors.New("duplicate monitor ID")
+	}
+	last, ok := lastID.(string)
+	if !ok {
+		return nil, fmt.Errorf("syntax error: expected transaction id, got %v", lastID)
+	}
+	m, err := newMonitor(db, id, requests, true)
+	if err != nil {
+		return nil, err
+	}
+	m.since = true
+	c.monitors[key] = m
+	if changes, ok := db.changesSince(uuid(last)); ok {
+		updates := m.updates(changes)
+		if updates == nil {
+			updates = map[string]interface{}{}
+		}
+		return []interface{}{true, db.txnID, updates}, nil
+	}
+	return []interface{}{false, db.txnID, m.initial()}, nil
+}
+
+// monitorCondChange changes the conditions of a monitor_cond and renames it to newID. Like
+// ovsdb-server, it sends the update2 for the rows entering or leaving the conditions before
+// the reply.
+func (c *conn) monitorCondChange(id, newID interface{}, requests interface{}) (interface{}, error) {
+	c.srv.mutex.Lock()
+	defer c.srv.mutex.Unlock()
+	key, newKey := monitorKey(id), monitorKey(newID)
+	m, ok := c.monitors[key]
+	if !ok || !m.cond {
+		return nil, errors.New("unknown monitor")
+	}
+	if _, ok := c.monitors[newKey]; ok && newKey != key {
+		return nil, errors.New("duplicate monitor ID")
+	}
+	updates, err := m.changeConditions(requests)
+	if err != nil {
+		return nil, err
+	}
+	delete(c.monitors, key)
+	m.id = newID
+	c.monitors[newKey] = m
+	if updates != nil {
+		c.send(m.notification(updates))
+	}
+	return map[string]interface{}{}, nil
+}
+
+// transact executes a transaction on db and notifies the monitors of its changes
+// before the result is sent
+func (srv *Server) transact(db *database, ops []interface{}) []interface{} {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+	results, changes := db.transact(ops)
+	if len(changes) > 0 {
+		for c := range srv.conns {
+			for _, m := range c.monitors {
+				if m.db != db {
+					continue
+				}
+				if updates := m.updates(changes); updates != nil {
+					c.send(m.notification(updates))
+				}
+			}
+		}
+	}
+	return results
+}
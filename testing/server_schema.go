@@ -0,0 +1,114 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ServerSchema is the schema of the _Server database every ovsdb-server has, it tells
+// clients the state of the other databases, see ovsdb-server(5)
+const ServerSchema = `{
+    "name": "_Server",
+    "version": "1.2.0",
+    "tables": {
+        "Database": {
+            "columns": {
+                "name": {"type": "string"},
+                "model": {
+                    "type": {"key": {"type": "string",
+                                     "enum": ["set", ["standalone", "clustered", "relay"]]}}},
+                "connected": {"type": "boolean"},
+                "leader": {"type": "boolean"},
+                "schema": {
+                    "type": {"key": {"type": "string"}, "min": 0, "max": 1}},
+                "cid": {
+                    "type": {"key": {"type": "uuid"}, "min": 0, "max": 1}},
+                "sid": {
+                    "type": {"key": {"type": "uuid"}, "min": 0, "max": 1}},
+                "index": {
+                    "type": {"key": {"type": "integer"}, "min": 0, "max": 1}}},
+            "isRoot": true}}
+}`
+
+const serverDB = "_Server"
+
+// addServerDatabase adds the _Server database describing the databases of srv as standalone
+func (srv *Server) addServerDatabase() error {
+	schema, err := parseSchema(ServerSchema)
+	if err != nil {
+		return err
+	}
+	db := newDatabase(schema)
+	var ops []interface{}
+	for name := range srv.dbs {
+		ops = append(ops, map[string]interface{}{
+			"op":    "insert",
+			"table": "Database",
+			"row":   map[string]interface{}{"name": name, "model": "standalone", "connected": true, "leader": true},
+		})
+	}
+	srv.dbs[serverDB] = db
+	_, err = srv.transactJSON(db, ops)
+	return err
+}
+
+// SetClusterState makes the database named name look like a member of a cluster, connected
+// to it or not and its leader or not, whose log is at index
+func (srv *Server) SetClusterState(name string, connected, leader bool, index int) error {
+	srv.mutex.Lock()
+	db, ok := srv.dbs[serverDB]
+	srv.mutex.Unlock()
+	if !ok || name == serverDB {
+		return fmt.Errorf("unknown database %q", name)
+	}
+	ops := []interface{}{map[string]interface{}{
+		"op":    "update",
+		"table": "Database",
+		"where": [][]interface{}{{"name", "==", name}},
+		"row":   map[string]interface{}{"model": "clustered", "connected": connected, "leader": leader, "index": index},
+	}}
+	results, err := srv.transactJSON(db, ops)
+	if err != nil {
+		return err
+	}
+	if count, _ := results[0].(map[string]interface{})["count"].(int); count != 1 {
+		return fmt.Errorf("unknown database %q", name)
+	}
+	return nil
+}
+
+// transactJSON runs ops given as Go values like the JSON a client would send
+func (srv *Server) transactJSON(db *database, ops []interface{}) ([]interface{}, error) {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return nil, err
+	}
+	var params []interface{}
+	if err := decodeJSON(data, &params); err != nil {
+		return nil, err
+	}
+	results := srv.transact(db, params)
+	for _, result := range results {
+		if r, ok := result.(map[string]interface{}); ok && r["error"] != nil {
+			return nil, errors.New(fmt.Sprint(r["error"], ": ", r["details"]))
+		}
+	}
+	return results, nil
+}
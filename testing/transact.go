@@ -0,0 +1,684 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+// rowChange is a row inserted (old is nil), deleted (new is nil) or modified by a transaction
+type rowChange struct {
+	old *row
+	new *row
+}
+
+// changeSet maps tables to the rows changed in them
+type changeSet map[string]map[uuid]rowChange
+
+// txn is a transaction being executed on a private copy of the tables of a database
+type txn struct {
+	db      *database
+	tables  map[string]map[uuid]*row
+	named   map[string]uuid
+	created map[string]bool
+}
+
+func newTxn(db *database) *txn {
+	t := &txn{
+		db:      db,
+		tables:  make(map[string]map[uuid]*row, len(db.tables)),
+		named:   make(map[string]uuid),
+		created: make(map[string]bool),
+	}
+	for name, rows := range db.tables {
+		t.tables[name] = make(map[uuid]*row, len(rows))
+		for u, r := range rows {
+			t.tables[name][u] = r
+		}
+	}
+	return t
+}
+
+func (t *txn) resolve(name string) uuid {
+	u, ok := t.named[name]
+	if !ok {
+		u = newUUID()
+		t.named[name] = u
+	}
+	return u
+}
+
+// transact executes the operations of a transact request, see RFC 7047 section 4.1.3.
+// It returns the results and the changes committed to db, if any.
+func (db *database) transact(ops []interface{}) ([]interface{}, changeSet) {
+	t := newTxn(db)
+	results := make([]interface{}, len(ops))
+	for i, op := range ops {
+		result, err := t.execute(op)
+		if err != nil {
+			results[i] = err
+			return results, nil
+		}
+		results[i] = result
+	}
+	for name := range t.named {
+		if !t.created[name] {
+			return append(results, syntaxError("named-uuid %s is not created by the transaction", name)), nil
+		}
+	}
+	if err := t.checkReferences(); err != nil {
+		return append(results, err), nil
+	}
+	if err := t.checkConstraints(); err != nil {
+		return append(results, err), nil
+	}
+	changes := t.changes()
+	db.tables = t.tables
+	if len(changes) > 0 {
+		db.commit(changes)
+	}
+	return results, changes
+}
+
+func (t *txn) execute(j interface{}) (interface{}, *ovsdbError) {
+	op, ok := j.(map[string]interface{})
+	if !ok {
+		return nil, syntaxError("expected operation, got %v", j)
+	}
+	name, _ := op["op"].(string)
+	switch name {
+	case "commit", "comment":
+		return map[string]interface{}{}, nil
+	case "abort":
+		return nil, newError("aborted", "aborted by request")
+	case "assert":
+		return nil, newError("not owner", "locks are not supported")
+	}
+	tableName, _ := op["table"].(string)
+	ts, ok := t.db.schema.tables[tableName]
+	if !ok {
+		return nil, syntaxError("unknown table %q", tableName)
+	}
+	switch name {
+	case "insert":
+		return t.insert(ts, op)
+	case "select", "update", "mutate", "delete", "wait":
+	default:
+		return nil, syntaxError("unknown operation %q", name)
+	}
+	where, ok := op["where"]
+	if !ok {
+		// unlike for monitor_cond, no where does not select all rows
+		return nil, syntaxError("required 'where' member is missing")
+	}
+	uuids, err := t.where(ts, where)
+	if err != nil {
+		return nil, err
+	}
+	switch name {
+	case "select":
+		return t.selectRows(ts, uuids, op["columns"])
+	case "update":
+		return t.update(ts, uuids, op["row"])
+	case "mutate":
+		return t.mutate(ts, uuids, op["mutations"])
+	case "delete":
+		for _, u := range uuids {
+			delete(t.tables[ts.name], u)
+		}
+		return map[string]interface{}{"count": len(uuids)}, nil
+	}
+	return t.wait(ts, uuids, op)
+}
+
+func (t *txn) insert(ts *tableSchema, op map[string]interface{}) (interface{}, *ovsdbError) {
+	u := newUUID()
+	if name, ok := op["uuid-name"].(string); ok {
+		if t.created[name] {
+			return nil, syntaxError("duplicate uuid-name %s", name)
+		}
+		u = t.resolve(name)
+		t.created[name] = true
+	}
+	r := &row{uuid: u, columns: make(map[string]datum, len(ts.columns))}
+	for column, ct := range ts.columns {
+		r.columns[column] = ct.defaultDatum()
+	}
+	if err := t.setColumns(ts, r, op["row"]); err != nil {
+		return nil, err
+	}
+	t.tables[ts.name][u] = r
+	return map[string]interface{}{"uuid": atomToJSON(u)}, nil
+}
+
+func (t *txn) setColumns(ts *tableSchema, r *row, j interface{}) *ovsdbError {
+	if j == nil {
+		return nil
+	}
+	values, ok := j.(map[string]interface{})
+	if !ok {
+		return syntaxError("expected row, got %v", j)
+	}
+	for column, value := range values {
+		ct, ok := ts.columns[column]
+		if !ok {
+			return syntaxError("unknown column %s in table %s", column, ts.name)
+		}
+		d, err := ct.parseDatum(value, t)
+		if err != nil {
+			return asOvsdbError(err)
+		}
+		r.columns[column] = d
+	}
+	return nil
+}
+
+func asOvsdbError(err error) *ovsdbError {
+	if e, ok := err.(*ovsdbError); ok {
+		return e
+	}
+	return syntaxError("%v", err)
+}
+
+// where returns the rows of ts matching conditions, see RFC 7047 section 5.1
+func (t *txn) where(ts *tableSchema, j interface{}) ([]uuid, *ovsdbError) {
+	conditions, ok := j.([]interface{})
+	if !ok {
+		return nil, syntaxError("expected conditions, got %v", j)
+	}
+	clauses := make([]clause, 0, len(conditions))
+	for _, c := range conditions {
+		cl, err := parseClause(ts, c, t)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, cl)
+	}
+	var uuids []uuid
+	for u, r := range t.tables[ts.name] {
+		match := true
+		for _, c := range clauses {
+			if !c.matches(r) {
+				match = false
+				break
+			}
+		}
+		if match {
+			uuids = append(uuids, u)
+		}
+	}
+	sort.Slice(uuids, func(i, j int) bool { return uuids[i] < uuids[j] })
+	return uuids, nil
+}
+
+// clause is a condition on a column, see RFC 7047 section 5.1
+type clause struct {
+	column   string
+	function string
+	value    datum
+}
+
+func parseClause(ts *tableSchema, j interface{}, syms symbols) (clause, *ovsdbError) {
+	condition, ok := j.([]interface{})
+	if !ok || len(condition) != 3 {
+		return clause{}, syntaxError("expected condition, got %v", j)
+	}
+	column, _ := condition[0].(string)
+	function, _ := condition[1].(string)
+	ct, ok := ts.columnType(column)
+	if !ok {
+		return clause{}, syntaxError("unknown column %s in table %s", column, ts.name)
+	}
+	switch function {
+	case "includes", "excludes":
+		ct = ct.relaxed()
+	case "==", "!=":
+	case "<", "<=", ">", ">=":
+		if ct.isMap() || ct.min != 1 || ct.max != 1 ||
+			(ct.key.atomic != typeInteger && ct.key.atomic != typeReal) {
+			return clause{}, syntaxError("%s is not allowed on column %s", function, column)
+		}
+	default:
+		return clause{}, syntaxError("unknown function %q", function)
+	}
+	value, err := ct.parseDatum(condition[2], syms)
+	if err != nil {
+		return clause{}, asOvsdbError(err)
+	}
+	return clause{column, function, value}, nil
+}
+
+func (c clause) matches(r *row) bool {
+	return evaluate(r.get(c.column), c.function, c.value)
+}
+
+func evaluate(d datum, function string, value datum) bool {
+	switch function {
+	case "==":
+		return d.equal(value)
+	case "!=":
+		return !d.equal(value)
+	case "includes":
+		return d.includes(value)
+	case "excludes":
+		return d.excludes(value)
+	}
+	cmp := compareAtoms(d.keys[0], value.keys[0])
+	switch function {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	}
+	return cmp >= 0
+}
+
+func (t *txn) selectRows(ts *tableSchema, uuids []uuid, j interface{}) (interface{}, *ovsdbError) {
+	columns, err := parseColumns(ts, j)
+	if err != nil {
+		return nil, err
+	}
+	if columns == nil {
+		columns = append(sortedColumns(ts), "_uuid", "_version")
+	}
+	rows := make([]interface{}, 0, len(uuids))
+	for _, u := range uuids {
+		rows = append(rows, t.tables[ts.name][u].toJSON(ts, columns))
+	}
+	return map[string]interface{}{"rows": rows}, nil
+}
+
+// parseColumns returns the column names in j, nil if j is nil
+func parseColumns(ts *tableSchema, j interface{}) ([]string, *ovsdbError) {
+	if j == nil {
+		return nil, nil
+	}
+	array, ok := j.([]interface{})
+	if !ok {
+		return nil, syntaxError("expected columns, got %v", j)
+	}
+	columns := make([]string, 0, len(array))
+	for _, c := range array {
+		column, _ := c.(string)
+		if _, ok := ts.columnType(column); !ok {
+			return nil, syntaxError("unknown column %v in table %s", c, ts.name)
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func (t *txn) update(ts *tableSchema, uuids []uuid, j interface{}) (interface{}, *ovsdbError) {
+	for _, u := range uuids {
+		r := t.tables[ts.name][u].clone()
+		if err := t.setColumns(ts, r, j); err != nil {
+			return nil, err
+		}
+		t.tables[ts.name][u] = r
+	}
+	return map[string]interface{}{"count": len(uuids)}, nil
+}
+
+func (t *txn) mutate(ts *tableSchema, uuids []uuid, j interface{}) (interface{}, *ovsdbError) {
+	mutations, ok := j.([]interface{})
+	if !ok {
+		return nil, syntaxError("expected mutations, got %v", j)
+	}
+	for _, u := range uuids {
+		r := t.tables[ts.name][u].clone()
+		for _, m := range mutations {
+			mutation, ok := m.([]interface{})
+			if !ok || len(mutation) != 3 {
+				return nil, syntaxError("expected mutation, got %v", m)
+			}
+			column, _ := mutation[0].(string)
+			mutator, _ := mutation[1].(string)
+			ct, ok := ts.columns[column]
+			if !ok {
+				if _, ok := ts.columnType(column); ok {
+					return nil, constraintViolation("cannot mutate column %s", column)
+				}
+				return nil, syntaxError("unknown column %s in table %s", column, ts.name)
+			}
+			d, err := t.mutateDatum(r.columns[column], ct, mutator, mutation[2])
+			if err != nil {
+				return nil, err
+			}
+			if len(d.keys) < ct.min || len(d.keys) > ct.max {
+				return nil, constraintViolation("mutation of column %s leaves %d elements where %d to %d are allowed",
+					column, len(d.keys), ct.min, ct.max)
+			}
+			r.columns[column] = d
+		}
+		t.tables[ts.name][u] = r
+	}
+	return map[string]interface{}{"count": len(uuids)}, nil
+}
+
+func (t *txn) mutateDatum(d datum, ct columnType, mutator string, j interface{}) (datum, *ovsdbError) {
+	switch mutator {
+	case "insert":
+		value, err := ct.relaxed().parseDatum(j, t)
+		if err != nil {
+			return d, asOvsdbError(err)
+		}
+		return d.union(value), nil
+	case "delete":
+		value, err := ct.relaxed().parseDatum(j, t)
+		if err != nil && ct.isMap() {
+			// the keys of the pairs to delete
+			keys := columnType{key: ct.key, max: unlimited}
+			value, err = keys.parseDatum(j, t)
+		}
+		if err != nil {
+			return d, asOvsdbError(err)
+		}
+		return d.difference(value), nil
+	case "+=", "-=", "*=", "/=", "%=":
+	default:
+		return d, syntaxError("unknown mutator %q", mutator)
+	}
+	if ct.isMap() || (ct.key.atomic != typeInteger && ct.key.atomic != typeReal) ||
+		(mutator == "%=" && ct.key.atomic != typeInteger) {
+		return d, constraintViolation("%s is not allowed on %s columns", mutator, ct.key.atomic)
+	}
+	operand, err := newBaseType(ct.key.atomic).parseAtom(j, t)
+	if err != nil {
+		return d, asOvsdbError(err)
+	}
+	r := datum{keys: make([]interface{}, 0, len(d.keys))}
+	for _, key := range d.keys {
+		var atom interface{}
+		if ct.key.atomic == typeInteger {
+			x, y := key.(int64), operand.(int64)
+			if (mutator == "/=" || mutator == "%=") && y == 0 {
+				return d, newError("domain error", "division by zero")
+			}
+			switch mutator {
+			case "+=":
+				atom = x + y
+			case "-=":
+				atom = x - y
+			case "*=":
+				atom = x * y
+			case "/=":
+				atom = x / y
+			case "%=":
+				atom = x % y
+			}
+			if v := atom.(int64); v < ct.key.minInteger || v > ct.key.maxInteger {
+				return d, constraintViolation("%d is not in range %d to %d", v, ct.key.minInteger, ct.key.maxInteger)
+			}
+		} else {
+			x, y := key.(float64), operand.(float64)
+			if mutator == "/=" && y == 0 {
+				return d, newError("domain error", "division by zero")
+			}
+			switch mutator {
+			case "+=":
+				atom = x + y
+			case "-=":
+				atom = x - y
+			case "*=":
+				atom = x * y
+			case "/=":
+				atom = x / y
+			}
+			if v := atom.(float64); v < ct.key.minReal || v > ct.key.maxReal {
+				return d, constraintViolation("%g is not in range %g to %g", v, ct.key.minReal, ct.key.maxReal)
+			}
+		}
+		r.keys = append(r.keys, atom)
+	}
+	r.sort()
+	return r, nil
+}
+
+// wait checks that the selected rows are (or are not) the given ones. The condition is
+// evaluated immediately: if it does not hold the operation fails with "timed out",
+// whatever the timeout, since there are no concurrent transactions to wait for.
+func (t *txn) wait(ts *tableSchema, uuids []uuid, op map[string]interface{}) (interface{}, *ovsdbError) {
+	columns, err := parseColumns(ts, op["columns"])
+	if err != nil {
+		return nil, err
+	}
+	if columns == nil {
+		columns = sortedColumns(ts)
+	}
+	until, _ := op["until"].(string)
+	if until != "==" && until != "!=" {
+		return nil, syntaxError("unknown until %q", op["until"])
+	}
+	rowsJSON, ok := op["rows"].([]interface{})
+	if !ok {
+		return nil, syntaxError("expected rows, got %v", op["rows"])
+	}
+	var expected []map[string]datum
+	for _, j := range rowsJSON {
+		values, ok := j.(map[string]interface{})
+		if !ok {
+			return nil, syntaxError("expected row, got %v", j)
+		}
+		r := make(map[string]datum, len(values))
+		for column, value := range values {
+			ct, ok := ts.columnType(column)
+			if !ok {
+				return nil, syntaxError("unknown column %s in table %s", column, ts.name)
+			}
+			d, err := ct.parseDatum(value, t)
+			if err != nil {
+				return nil, asOvsdbError(err)
+			}
+			r[column] = d
+		}
+		expected = append(expected, r)
+	}
+
+	equal := len(expected) == len(uuids)
+	matched := make(map[uuid]bool)
+	for _, e := range expected {
+		if !equal {
+			break
+		}
+		found := false
+		for _, u := range uuids {
+			if matched[u] {
+				continue
+			}
+			r := t.tables[ts.name][u]
+			same := true
+			for _, column := range columns {
+				if d, ok := e[column]; !ok || !r.get(column).equal(d) {
+					same = false
+					break
+				}
+			}
+			if same {
+				matched[u] = true
+				found = true
+				break
+			}
+		}
+		equal = found
+	}
+	if equal != (until == "==") {
+		return nil, newError("timed out", "\"wait\" timed out")
+	}
+	return map[string]interface{}{}, nil
+}
+
+// references calls fn for every reference of every row, fn returns whether to drop it
+func (t *txn) references(fn func(ts *tableSchema, r *row, column string, base *baseType, target uuid) bool) {
+	for name, rows := range t.tables {
+		ts := t.db.schema.tables[name]
+		for _, column := range sortedColumns(ts) {
+			ct := ts.columns[column]
+			if ct.key.refTable == "" && (ct.value == nil || ct.value.refTable == "") {
+				continue
+			}
+			for u, r := range rows {
+				d := r.columns[column]
+				var kept datum
+				dropped := false
+				for i, key := range d.keys {
+					drop := false
+					if ct.key.refTable != "" {
+						drop = fn(ts, r, column, &ct.key, key.(uuid))
+					}
+					if ct.value != nil && ct.value.refTable != "" {
+						drop = fn(ts, r, column, ct.value, d.values[i].(uuid)) || drop
+					}
+					if drop {
+						dropped = true
+						continue
+					}
+					kept.keys = append(kept.keys, key)
+					if ct.isMap() {
+						kept.values = append(kept.values, d.values[i])
+					}
+				}
+				if dropped {
+					r = r.clone()
+					r.columns[column] = kept
+					rows[u] = r
+				}
+			}
+		}
+	}
+}
+
+// checkReferences removes weak references to missing rows, fails on strong references to
+// missing rows and deletes the rows of non-root tables that are not strongly referenced,
+// see RFC 7047 section 3.2
+func (t *txn) checkReferences() *ovsdbError {
+	for {
+		var err *ovsdbError
+		referenced := make(map[uuid]bool)
+		t.references(func(ts *tableSchema, r *row, column string, base *baseType, target uuid) bool {
+			if _, ok := t.tables[base.refTable][target]; ok {
+				if base.refType == refStrong {
+					referenced[target] = true
+				}
+				return false
+			}
+			if base.refType == refWeak {
+				return true
+			}
+			if err == nil {
+				err = newError("referential integrity violation",
+					"row %s in table %s column %s refers to nonexistent row %s in table %s",
+					r.uuid, ts.name, column, target, base.refTable)
+			}
+			return false
+		})
+		if err != nil {
+			return err
+		}
+		collected := false
+		for name, rows := range t.tables {
+			if t.db.schema.tables[name].isRoot {
+				continue
+			}
+			for u := range rows {
+				if !referenced[u] {
+					delete(rows, u)
+					collected = true
+				}
+			}
+		}
+		if !collected {
+			return nil
+		}
+	}
+}
+
+// checkConstraints checks the number of rows of tables and the uniqueness of indexes
+func (t *txn) checkConstraints() *ovsdbError {
+	for name, rows := range t.tables {
+		ts := t.db.schema.tables[name]
+		if ts.maxRows > 0 && len(rows) > ts.maxRows {
+			return constraintViolation("transaction causes %s table to contain %d rows, greater than the schema-defined limit of %d row(s)",
+				name, len(rows), ts.maxRows)
+		}
+		for _, index := range ts.indexes {
+			seen := make(map[string]uuid)
+			for u, r := range rows {
+				values := make([]interface{}, 0, len(index))
+				for _, column := range index {
+					values = append(values, r.get(column).toJSON(ts.columns[column]))
+				}
+				b, _ := json.Marshal(values)
+				if other, ok := seen[string(b)]; ok {
+					return constraintViolation("Transaction causes multiple rows in \"%s\" table to have identical values (%s) for index on column(s) %s. Rows %s and %s",
+						name, strings.Trim(string(b), "[]"), strings.Join(index, ", "), other, u)
+				}
+				seen[string(b)] = u
+			}
+		}
+	}
+	return nil
+}
+
+// changes returns the rows changed by the transaction, giving them a new version
+func (t *txn) changes() changeSet {
+	changes := make(changeSet)
+	add := func(table string, u uuid, old, new *row) {
+		if changes[table] == nil {
+			changes[table] = make(map[uuid]rowChange)
+		}
+		changes[table][u] = rowChange{old: old, new: new}
+	}
+	for name, rows := range t.tables {
+		oldRows := t.db.tables[name]
+		for u, r := range rows {
+			old, ok := oldRows[u]
+			if !ok {
+				r.version = newUUID()
+				add(name, u, nil, r)
+				continue
+			}
+			if old == r {
+				continue
+			}
+			if rowsEqual(old, r) {
+				rows[u] = old
+				continue
+			}
+			r.version = newUUID()
+			add(name, u, old, r)
+		}
+		for u, old := range oldRows {
+			if _, ok := rows[u]; !ok {
+				add(name, u, old, nil)
+			}
+		}
+	}
+	return changes
+}
+
+func rowsEqual(a, b *row) bool {
+	for column, d := range a.columns {
+		if !b.columns[column].equal(d) {
+			return false
+		}
+	}
+	return true
+}
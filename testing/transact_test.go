@@ -0,0 +1,139 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDatabase(t *testing.T) *database {
+	schema, err := parseSchema(NBSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newDatabase(schema)
+}
+
+// transact runs a transaction given in JSON and returns its results as JSON values
+func transact(t *testing.T, db *database, ops string) []map[string]interface{} {
+	var params []interface{}
+	if err := decodeJSON([]byte(ops), &params); err != nil {
+		t.Fatal(err)
+	}
+	results, _ := db.transact(params)
+	b, err := json.Marshal(results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var j []map[string]interface{}
+	if err = json.Unmarshal(b, &j); err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+// lastError returns the error of the failed operation or commit, "" on success
+func lastError(results []map[string]interface{}) string {
+	for _, r := range results {
+		if r == nil {
+			break
+		}
+		if err, ok := r["error"].(string); ok {
+			return err
+		}
+	}
+	return ""
+}
+
+func TestTransactReferences(t *testing.T) {
+	db := newTestDatabase(t)
+
+	results := transact(t, db, `[
+		{"op": "insert", "table": "Logical_Switch_Port", "row": {"name": "lsp1"}, "uuid-name": "lsp1"},
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls1",
+			"ports": ["named-uuid", "lsp1"]}},
+		{"op": "insert", "table": "Port_Group", "row": {"name": "pg1",
+			"ports": ["set", [["named-uuid", "lsp1"]]]}}]`)
+	assert.Equal(t, "", lastError(results))
+	assert.Equal(t, 1, len(db.tables["Logical_Switch_Port"]))
+
+	// a port that is not referenced by a switch is garbage collected
+	results = transact(t, db, `[{"op": "insert", "table": "Logical_Switch_Port", "row": {"name": "lsp2"}}]`)
+	assert.Equal(t, "", lastError(results))
+	assert.Equal(t, 1, len(db.tables["Logical_Switch_Port"]))
+
+	// strong references must not dangle
+	results = transact(t, db, `[{"op": "delete", "table": "Logical_Switch_Port", "where": []}]`)
+	assert.Equal(t, "referential integrity violation", lastError(results))
+
+	// removing the port from the switch deletes it and the weak reference of the port group
+	results = transact(t, db, `[
+		{"op": "update", "table": "Logical_Switch", "where": [["name", "==", "ls1"]],
+			"row": {"ports": ["set", []]}}]`)
+	assert.Equal(t, "", lastError(results))
+	assert.Equal(t, 0, len(db.tables["Logical_Switch_Port"]))
+	results = transact(t, db, `[{"op": "select", "table": "Port_Group", "where": [], "columns": ["ports"]}]`)
+	assert.Equal(t, []interface{}{map[string]interface{}{"ports": []interface{}{"set", []interface{}{}}}},
+		results[0]["rows"])
+}
+
+func TestTransactConstraints(t *testing.T) {
+	db := newTestDatabase(t)
+
+	results := transact(t, db, `[
+		{"op": "insert", "table": "Address_Set", "row": {"name": "as1"}},
+		{"op": "insert", "table": "Address_Set", "row": {"name": "as1"}}]`)
+	assert.Equal(t, 3, len(results))
+	assert.Equal(t, "constraint violation", lastError(results))
+	assert.Equal(t, 0, len(db.tables["Address_Set"]))
+
+	results = transact(t, db, `[{"op": "insert", "table": "ACL", "row": {"direction": "sideways"}}]`)
+	assert.Equal(t, "constraint violation", lastError(results))
+
+	results = transact(t, db, `[{"op": "insert", "table": "Logical_Switch", "row": {"mtu": 1500}}]`)
+	assert.Equal(t, "syntax error", lastError(results))
+
+	// all rows are selected by an empty where, not by a missing one
+	results = transact(t, db, `[{"op": "delete", "table": "Address_Set"}]`)
+	assert.Equal(t, "syntax error", lastError(results))
+}
+
+func TestTransactMutateWait(t *testing.T) {
+	db := newTestDatabase(t)
+
+	results := transact(t, db, `[
+		{"op": "insert", "table": "Logical_Switch", "row": {"name": "ls1",
+			"external_ids": ["map", [["a", "1"], ["b", "2"]]]}},
+		{"op": "mutate", "table": "Logical_Switch", "where": [["name", "==", "ls1"]],
+			"mutations": [["external_ids", "delete", ["set", ["a"]]],
+				["external_ids", "insert", ["map", [["b", "3"], ["c", "3"]]]]]},
+		{"op": "wait", "table": "Logical_Switch", "where": [["name", "==", "ls1"]], "timeout": 0,
+			"columns": ["external_ids"], "until": "==",
+			"rows": [{"external_ids": ["map", [["b", "2"], ["c", "3"]]]}]}]`)
+	assert.Equal(t, "", lastError(results))
+
+	results = transact(t, db, `[
+		{"op": "wait", "table": "Logical_Switch", "where": [], "timeout": 0,
+			"columns": ["name"], "until": "==", "rows": []},
+		{"op": "delete", "table": "Logical_Switch", "where": []}]`)
+	assert.Equal(t, "timed out", lastError(results))
+	assert.Nil(t, results[1])
+	assert.Equal(t, 1, len(db.tables["Logical_Switch"]))
+}
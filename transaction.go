@@ -0,0 +1,552 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/ebay/libovsdb"
+)
+
+// Number of times Transact re-runs a transaction that failed with ErrorConflict
+const transactRetries = 5
+
+// Transaction stages multiple OvnCommands and commits them as a single OVSDB transaction.
+// Commands are built with the embedded Client, which validates against the client cache
+// plus everything staged so far, so e.g. LSPAdd can be staged on a switch added by an
+// earlier LSAdd in the same transaction. Execute stages commands instead of sending them.
+// Commit always verifies that the cached rows the commands were validated against did not
+// change, see Config.VerifyCache.
+type Transaction interface {
+	Client
+	// Send all staged commands in one transaction, ErrorConflict if cached rows changed
+	Commit() error
+	// Commit giving up with ctx.Err() when ctx is done, staged commands are kept then
+	CommitContext(ctx context.Context) error
+	// Discard all staged commands
+	Rollback()
+}
+
+type transaction struct {
+	// private view of the cache that staged commands are applied to
+	*ovndb
+	parent *ovndb
+	cmds   []*OvnCommand
+}
+
+type undoEntry struct {
+	table  string
+	uuid   string
+	row    libovsdb.Row
+	exists bool
+}
+
+func (odbi *ovndb) newTransactionImp() *transaction {
+	txn := &transaction{parent: odbi}
+	txn.ovndb = &ovndb{
+		db:        odbi.db,
+		addr:      odbi.addr,
+		tableCols: odbi.tableCols,
+		tlsConfig: odbi.tlsConfig,
+		txn:       txn,
+	}
+	txn.reset()
+	return txn
+}
+
+func (odbi *ovndb) transactImp(ctx context.Context, fn func(txn Transaction) error) error {
+	var err error
+	for i := 0; i <= transactRetries; i++ {
+		txn := odbi.newTransactionImp()
+		if err = fn(txn); err != nil {
+			return err
+		}
+		err = txn.CommitContext(ctx)
+		if err != ErrorConflict {
+			return err
+		}
+	}
+	return err
+}
+
+// snapshotCache returns a copy of the cache that can be modified without affecting it.
+// Rows are shared, since the cache replaces rows rather than modifying them.
+func (odbi *ovndb) snapshotCache() map[string]map[string]libovsdb.Row {
+	odbi.cachemutex.RLock()
+	defer odbi.cachemutex.RUnlock()
+
+	snapshot := make(map[string]map[string]libovsdb.Row, len(odbi.cache))
+	for table, rows := range odbi.cache {
+		snapshot[table] = make(map[string]libovsdb.Row, len(rows))
+		for uuid, row := range rows {
+			snapshot[table][uuid] = row
+		}
+	}
+	return snapshot
+}
+
+func (txn *transaction) reset() {
+	snapshot := txn.parent.snapshotCache()
+	txn.cachemutex.Lock()
+	defer txn.cachemutex.Unlock()
+	txn.cache = snapshot
+	txn.cmds = nil
+}
+
+func (txn *transaction) Commit() error {
+	return txn.CommitContext(context.Background())
+}
+
+func (txn *transaction) CommitContext(ctx context.Context) error {
+	if len(txn.cmds) == 0 {
+		return nil
+	}
+	// always verify, so that a conflicting change can be retried by Transact
+	err := txn.parent.executeImp(ctx, true, txn.cmds...)
+	if err == ctx.Err() && err != nil {
+		return err
+	}
+	txn.reset()
+	return err
+}
+
+func (txn *transaction) Rollback() {
+	txn.reset()
+}
+
+// stage applies the operations of cmds to the transaction's view of the cache.
+// Either all of cmds are staged or, on error, none of them.
+func (txn *transaction) stage(cmds ...*OvnCommand) error {
+	txn.cachemutex.Lock()
+	defer txn.cachemutex.Unlock()
+
+	var undo []undoEntry
+	for _, cmd := range cmds {
+		if cmd == nil {
+			continue
+		}
+		for _, op := range cmd.Operations {
+			if err := txn.applyOperation(op, &undo); err != nil {
+				for i := len(undo) - 1; i >= 0; i-- {
+					u := undo[i]
+					if u.exists {
+						txn.cache[u.table][u.uuid] = u.row
+					} else {
+						delete(txn.cache[u.table], u.uuid)
+					}
+				}
+				return err
+			}
+		}
+	}
+	for _, cmd := range cmds {
+		if cmd != nil {
+			txn.cmds = append(txn.cmds, cmd)
+		}
+	}
+	return nil
+}
+
+func (txn *transaction) setRow(table, uuid string, row *libovsdb.Row, undo *[]undoEntry) {
+	if _, ok := txn.cache[table]; !ok {
+		txn.cache[table] = make(map[string]libovsdb.Row)
+	}
+	old, exists := txn.cache[table][uuid]
+	*undo = append(*undo, undoEntry{table, uuid, old, exists})
+	if row == nil {
+		delete(txn.cache[table], uuid)
+	} else {
+		txn.cache[table][uuid] = *row
+	}
+}
+
+func (txn *transaction) applyOperation(op libovsdb.Operation, undo *[]undoEntry) error {
+	switch op.Op {
+	case opInsert:
+		uuid := op.UUIDName
+		if len(uuid) == 0 {
+			var err error
+			if uuid, err = newRowUUID(); err != nil {
+				return err
+			}
+		}
+		row := libovsdb.Row{Fields: txn.parent.defaultRow(op.Table)}
+		for column, value := range op.Row {
+			datum, err := normalizeDatum(value)
+			if err != nil {
+				return err
+			}
+			row.Fields[column] = datum
+		}
+		txn.setRow(op.Table, uuid, &row, undo)
+	case opUpdate, opMutate, opDelete:
+		uuids, err := matchRows(txn.cache[op.Table], op.Where)
+		if err != nil {
+			return err
+		}
+		for _, uuid := range uuids {
+			if op.Op == opDelete {
+				txn.setRow(op.Table, uuid, nil, undo)
+				continue
+			}
+			row := libovsdb.Row{Fields: make(map[string]interface{})}
+			for column, value := range txn.cache[op.Table][uuid].Fields {
+				row.Fields[column] = value
+			}
+			if op.Op == opUpdate {
+				for column, value := range op.Row {
+					datum, err := normalizeDatum(value)
+					if err != nil {
+						return err
+					}
+					row.Fields[column] = datum
+				}
+			} else {
+				for _, m := range op.Mutations {
+					if err := mutateRow(row, m); err != nil {
+						return err
+					}
+				}
+			}
+			txn.setRow(op.Table, uuid, &row, undo)
+		}
+	}
+	return nil
+}
+
+// defaultRow returns a row with every column of table set to its default value
+func (odbi *ovndb) defaultRow(table string) map[string]interface{} {
+	fields := make(map[string]interface{})
+	schema, ok := odbi.getSchema()
+	if !ok {
+		return fields
+	}
+	for column, columnSchema := range schema.Tables[table].Columns {
+		fields[column] = defaultDatum(columnSchema.Type)
+	}
+	return fields
+}
+
+// defaultDatum returns the default value of a column with the given schema type, see RFC 7047 section 5.1
+func defaultDatum(columnType interface{}) interface{} {
+	switch t := columnType.(type) {
+	case string:
+		return defaultAtom(t)
+	case map[string]interface{}:
+		if _, ok := t["value"]; ok {
+			return libovsdb.OvsMap{GoMap: make(map[interface{}]interface{})}
+		}
+		if isSetType(t) {
+			return libovsdb.OvsSet{}
+		}
+		switch key := t["key"].(type) {
+		case string:
+			return defaultAtom(key)
+		case map[string]interface{}:
+			if keyType, ok := key["type"].(string); ok {
+				return defaultAtom(keyType)
+			}
+		}
+	}
+	return nil
+}
+
+// isSetType tells whether a column with the given schema type is a set or a map,
+// rather than exactly one atom
+func isSetType(columnType interface{}) bool {
+	t, ok := columnType.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if _, ok := t["value"]; ok {
+		return true
+	}
+	min, max := 1.0, interface{}(1.0)
+	if v, ok := t["min"].(float64); ok {
+		min = v
+	}
+	if v, ok := t["max"]; ok {
+		max = v
+	}
+	return min == 0 || max != 1.0
+}
+
+func defaultAtom(atomicType string) interface{} {
+	switch atomicType {
+	case "integer", "real":
+		return 0
+	case "boolean":
+		return false
+	case "uuid":
+		return stringToGoUUID("00000000-0000-0000-0000-000000000000")
+	}
+	return ""
+}
+
+// normalizeDatum converts a value as used in an operation to the form the cache
+// holds for values received from the server.
+func normalizeDatum(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var raw interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	datum := jsonToDatum(raw)
+	if set, ok := datum.(libovsdb.OvsSet); ok && len(set.GoSet) == 1 {
+		// a set with a single element is sent by the server as that element
+		return set.GoSet[0], nil
+	}
+	return datum, nil
+}
+
+func jsonToDatum(raw interface{}) interface{} {
+	switch v := raw.(type) {
+	case float64:
+		if n := int(v); float64(n) == v {
+			return n
+		}
+		return v
+	case []interface{}:
+		if len(v) == 2 {
+			if tag, ok := v[0].(string); ok {
+				switch tag {
+				case "uuid", "named-uuid":
+					if uuid, ok := v[1].(string); ok {
+						return stringToGoUUID(uuid)
+					}
+				case "set":
+					elems, _ := v[1].([]interface{})
+					set := libovsdb.OvsSet{}
+					for _, e := range elems {
+						set.GoSet = append(set.GoSet, jsonToDatum(e))
+					}
+					return set
+				case "map":
+					pairs, _ := v[1].([]interface{})
+					m := libovsdb.OvsMap{GoMap: make(map[interface{}]interface{})}
+					for _, p := range pairs {
+						if pair, ok := p.([]interface{}); ok && len(pair) == 2 {
+							m.GoMap[jsonToDatum(pair[0])] = jsonToDatum(pair[1])
+						}
+					}
+					return m
+				}
+			}
+		}
+		// a plain list of atoms
+		set := libovsdb.OvsSet{}
+		for _, e := range v {
+			set.GoSet = append(set.GoSet, jsonToDatum(e))
+		}
+		return set
+	}
+	return raw
+}
+
+// datumToAtoms returns the elements of a set, or the atom itself as a single element
+func datumToAtoms(datum interface{}) []interface{} {
+	switch v := datum.(type) {
+	case libovsdb.OvsSet:
+		return v.GoSet
+	case nil:
+		return nil
+	}
+	return []interface{}{datum}
+}
+
+func atomsToDatum(atoms []interface{}) interface{} {
+	if len(atoms) == 1 {
+		return atoms[0]
+	}
+	return libovsdb.OvsSet{GoSet: atoms}
+}
+
+func containsAtom(atoms []interface{}, atom interface{}) bool {
+	for _, a := range atoms {
+		if reflect.DeepEqual(a, atom) {
+			return true
+		}
+	}
+	return false
+}
+
+func datumEqual(a, b interface{}) bool {
+	amap, aok := a.(libovsdb.OvsMap)
+	bmap, bok := b.(libovsdb.OvsMap)
+	if aok || bok {
+		return aok && bok && len(amap.GoMap) == len(bmap.GoMap) && datumIncludes(a, b)
+	}
+	as, bs := datumToAtoms(a), datumToAtoms(b)
+	if len(as) != len(bs) {
+		return false
+	}
+	for _, atom := range as {
+		if !containsAtom(bs, atom) {
+			return false
+		}
+	}
+	return true
+}
+
+func datumIncludes(a, b interface{}) bool {
+	amap, aok := a.(libovsdb.OvsMap)
+	bmap, bok := b.(libovsdb.OvsMap)
+	if aok && bok {
+		for k, v := range bmap.GoMap {
+			if av, ok := amap.GoMap[k]; !ok || !reflect.DeepEqual(av, v) {
+				return false
+			}
+		}
+		return true
+	}
+	as := datumToAtoms(a)
+	for _, atom := range datumToAtoms(b) {
+		if !containsAtom(as, atom) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchRows returns the UUIDs of the rows that satisfy all conditions in where, see RFC 7047 section 5.1
+func matchRows(rows map[string]libovsdb.Row, where []interface{}) ([]string, error) {
+	var uuids []string
+	for uuid, row := range rows {
+		match, err := rowMatches(uuid, row, where)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			uuids = append(uuids, uuid)
+		}
+	}
+	return uuids, nil
+}
+
+func rowMatches(uuid string, row libovsdb.Row, where []interface{}) (bool, error) {
+	for _, c := range where {
+		cond, ok := c.([]interface{})
+		if !ok || len(cond) != 3 {
+			return false, fmt.Errorf("invalid condition %v", c)
+		}
+		column, _ := cond[0].(string)
+		function, _ := cond[1].(string)
+		value, err := normalizeDatum(cond[2])
+		if err != nil {
+			return false, err
+		}
+		var current interface{}
+		if column == "_uuid" {
+			current = stringToGoUUID(uuid)
+		} else if current, ok = row.Fields[column]; !ok {
+			return false, fmt.Errorf("column %s not found in cache", column)
+		}
+		var match bool
+		switch function {
+		case "==":
+			match = datumEqual(current, value)
+		case "!=":
+			match = !datumEqual(current, value)
+		case "includes":
+			match = datumIncludes(current, value)
+		case "excludes":
+			match = !datumIncludes(current, value)
+		default:
+			return false, fmt.Errorf("unsupported condition function %s", function)
+		}
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mutateRow applies a single mutation of a mutate operation to row, see RFC 7047 section 5.2.6
+func mutateRow(row libovsdb.Row, m interface{}) error {
+	mutation, ok := m.([]interface{})
+	if !ok || len(mutation) != 3 {
+		return fmt.Errorf("invalid mutation %v", m)
+	}
+	column, _ := mutation[0].(string)
+	mutator, _ := mutation[1].(string)
+	value, err := normalizeDatum(mutation[2])
+	if err != nil {
+		return err
+	}
+	current := row.Fields[column]
+
+	if cmap, ok := current.(libovsdb.OvsMap); ok {
+		result := libovsdb.OvsMap{GoMap: make(map[interface{}]interface{})}
+		for k, v := range cmap.GoMap {
+			result.GoMap[k] = v
+		}
+		switch mutator {
+		case opInsert:
+			if vmap, ok := value.(libovsdb.OvsMap); ok {
+				for k, v := range vmap.GoMap {
+					if _, exists := result.GoMap[k]; !exists {
+						result.GoMap[k] = v
+					}
+				}
+			}
+		case opDelete:
+			if vmap, ok := value.(libovsdb.OvsMap); ok {
+				for k, v := range vmap.GoMap {
+					if reflect.DeepEqual(result.GoMap[k], v) {
+						delete(result.GoMap, k)
+					}
+				}
+			} else {
+				for _, k := range datumToAtoms(value) {
+					delete(result.GoMap, k)
+				}
+			}
+		default:
+			return fmt.Errorf("unsupported mutator %s for map column %s", mutator, column)
+		}
+		row.Fields[column] = result
+		return nil
+	}
+
+	var atoms []interface{}
+	switch mutator {
+	case opInsert:
+		atoms = append(atoms, datumToAtoms(current)...)
+		for _, atom := range datumToAtoms(value) {
+			if !containsAtom(atoms, atom) {
+				atoms = append(atoms, atom)
+			}
+		}
+	case opDelete:
+		remove := datumToAtoms(value)
+		for _, atom := range datumToAtoms(current) {
+			if !containsAtom(remove, atom) {
+				atoms = append(atoms, atom)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported mutator %s for column %s", mutator, column)
+	}
+	row.Fields[column] = atomsToDatum(atoms)
+	return nil
+}
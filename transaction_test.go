@@ -0,0 +1,345 @@
+/**
+ * Copyright (c) 2020 eBay Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *  http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ **/
+
+package goovn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ebay/libovsdb"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TXN_TEST_LS   = "TXN_TEST_LS"
+	TXN_TEST_LSP1 = "TXN_TEST_LSP1"
+	TXN_TEST_LSP2 = "TXN_TEST_LSP2"
+	TXN_TEST_ADDR = "00:00:00:00:00:01 10.0.0.1"
+)
+
+func TestTransaction(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	txn := ovndbapi.NewTransaction()
+
+	// stage a switch and ports on it in the same transaction
+	cmd, err := txn.LSAdd(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	for _, lsp := range []string{TXN_TEST_LSP1, TXN_TEST_LSP2} {
+		cmd, err = txn.LSPAdd(TXN_TEST_LS, lsp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = txn.Execute(cmd); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cmd, err = txn.LSPSetAddress(TXN_TEST_LSP1, TXN_TEST_ADDR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	// staged objects are visible to the transaction only
+	_, err = txn.LSAdd(TXN_TEST_LS)
+	assert.Equal(t, ErrorExist, err)
+	lsps, err := txn.LSPList(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(lsps))
+	_, err = ovndbapi.LSGet(TXN_TEST_LS)
+	assert.Equal(t, ErrorNotFound, err)
+
+	if err = txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	lsps, err = ovndbapi.LSPList(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, len(lsps))
+	lsp, err := ovndbapi.LSPGet(TXN_TEST_LSP1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{TXN_TEST_ADDR}, lsp.Addresses)
+
+	// rolled back commands are never sent
+	cmd, err = txn.LSPDel(TXN_TEST_LSP2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	_, err = txn.LSPGet(TXN_TEST_LSP2)
+	assert.Equal(t, ErrorNotFound, err)
+	txn.Rollback()
+	if err = txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = ovndbapi.LSPGet(TXN_TEST_LSP2)
+	assert.Nil(t, err)
+
+	cmd, err = ovndbapi.LSDel(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ovndbapi.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransactRetry(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	attempts := 0
+	err := ovndbapi.Transact(func(txn Transaction) error {
+		attempts++
+		cmd, err := txn.LSAdd(TXN_TEST_LS)
+		if err != nil {
+			return err
+		}
+		if attempts == 1 {
+			// a wait precondition that never holds: a switch that has no name
+			cmd.Operations = append([]libovsdb.Operation{{
+				Op:      opWait,
+				Table:   TableLogicalSwitch,
+				Timeout: 1,
+				Where:   []interface{}{libovsdb.NewCondition("name", "==", "")},
+				Columns: []string{"name"},
+				Until:   "==",
+				Rows:    []map[string]interface{}{{"name": TXN_TEST_LS}},
+			}}, cmd.Operations...)
+		}
+		return txn.Execute(cmd)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, attempts)
+
+	ls, err := ovndbapi.LSGet(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(ls))
+
+	cmd, err := ovndbapi.LSDel(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ovndbapi.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyConflict(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	other := getOVNClient(DBNB)
+	defer other.Close()
+
+	cmd, err := ovndbapi.LSAdd(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ovndbapi.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	// validated against the switch in the cache
+	delCmd, err := ovndbapi.LSDel(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = delCmd.Verify(); err != nil {
+		t.Fatal(err)
+	}
+
+	// another client replaces the switch meanwhile
+	cmd, err = other.LSDel(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = other.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = other.LSAdd(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = other.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, ErrorConflict, ovndbapi.Execute(delCmd))
+	_, err = other.LSGet(TXN_TEST_LS)
+	assert.Nil(t, err)
+
+	cmd, err = other.LSDel(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = other.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyInsert(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+
+	cmd, err := ovndbapi.LSAdd(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ovndbapi.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	// no switch may have the name of the one inserted
+	cmd, err = ovndbapi.LSAdd(TXN_TEST_LS + "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cmd.Verify(); err != nil {
+		t.Fatal(err)
+	}
+	if err = ovndbapi.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	// transactions always verify
+	txn := ovndbapi.NewTransaction()
+	cmd, err = txn.LSAdd(TXN_TEST_LS + "3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, ls := range []string{TXN_TEST_LS, TXN_TEST_LS + "2", TXN_TEST_LS + "3"} {
+		cmd, err = ovndbapi.LSDel(ls)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = ovndbapi.Execute(cmd); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVerifyInsertEmptyTable(t *testing.T) {
+	ovndbapi := getOVNClient(DBNB)
+	defer ovndbapi.Close()
+	other := getOVNClient(DBNB)
+	defer other.Close()
+
+	// both clients create the same port group in a table without rows, the second fails
+	var cmds []*OvnCommand
+	for _, api := range []Client{ovndbapi, other} {
+		cmd, err := api.PortGroupAdd(TXN_TEST_LS, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = cmd.Verify(); err != nil {
+			t.Fatal(err)
+		}
+		if assert.Equal(t, 2, len(cmd.Operations)) {
+			assert.Equal(t, opWait, cmd.Operations[0].Op)
+		}
+		cmds = append(cmds, cmd)
+	}
+	if err := ovndbapi.Execute(cmds[0]); err != nil {
+		t.Fatal(err)
+	}
+	err := other.Execute(cmds[1])
+	assert.True(t, isError(err, ErrorConflict), err)
+
+	cmd, err := ovndbapi.PortGroupDel(TXN_TEST_LS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ovndbapi.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestTransactionSchema(t *testing.T) {
+	// commands looking up the schema of the database are staged like the others
+	nbapi := getOVNClient(DBNB)
+	defer nbapi.Close()
+	txn := nbapi.NewTransaction()
+	defer txn.Rollback()
+	cmd, err := txn.LRAdd(TXN_TEST_LS, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+	cmd, err = txn.LRPolicyAdd(TXN_TEST_LS, 100, "ip4.src == 10.0.0.0/24", LRPolicyActionReroute,
+		[]string{"10.1.0.1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = txn.Execute(cmd); err != nil {
+		t.Fatal(err)
+	}
+
+	sbapi := getOVNClientOnRequest()
+	defer sbapi.Close()
+	// the connections belong to SB_Global
+	withGlobalRow(t, sbapi, func() {
+		txn = sbapi.NewTransaction()
+		defer txn.Rollback()
+		cmd, err = txn.ConnectionSet(&Connection{Target: "pssl:6642", Role: "ovn-controller"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = txn.Execute(cmd); err != nil {
+			t.Fatal(err)
+		}
+		fdbs, err := txn.FDBList()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, len(fdbs))
+		chassis, err := txn.ChassisListLagging(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, len(chassis))
+		lflows, err := txn.LogicalFlowList(FAKENOSWITCH, "", -1, -1, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 0, len(lflows))
+		_, err = txn.MACBindingDelOlderThan(time.Hour)
+		assert.True(t, isError(err, ErrorNoChanges), err)
+	})
+}